@@ -132,6 +132,7 @@ func (a *BanksAPIService) AddBankBackgroundExecute(r ApiAddBankBackgroundRequest
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -151,6 +152,7 @@ func (a *BanksAPIService) AddBankBackgroundExecute(r ApiAddBankBackgroundRequest
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -254,6 +256,7 @@ func (a *BanksAPIService) ClearObservationsExecute(r ApiClearObservationsRequest
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -273,6 +276,7 @@ func (a *BanksAPIService) ClearObservationsExecute(r ApiClearObservationsRequest
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -387,6 +391,7 @@ func (a *BanksAPIService) CreateOrUpdateBankExecute(r ApiCreateOrUpdateBankReque
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -406,6 +411,7 @@ func (a *BanksAPIService) CreateOrUpdateBankExecute(r ApiCreateOrUpdateBankReque
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -509,6 +515,7 @@ func (a *BanksAPIService) DeleteBankExecute(r ApiDeleteBankRequest) (*DeleteResp
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -528,6 +535,7 @@ func (a *BanksAPIService) DeleteBankExecute(r ApiDeleteBankRequest) (*DeleteResp
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -631,6 +639,7 @@ func (a *BanksAPIService) GetAgentStatsExecute(r ApiGetAgentStatsRequest) (*Bank
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -650,6 +659,7 @@ func (a *BanksAPIService) GetAgentStatsExecute(r ApiGetAgentStatsRequest) (*Bank
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -753,6 +763,7 @@ func (a *BanksAPIService) GetBankConfigExecute(r ApiGetBankConfigRequest) (*Bank
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -772,6 +783,7 @@ func (a *BanksAPIService) GetBankConfigExecute(r ApiGetBankConfigRequest) (*Bank
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -878,6 +890,7 @@ func (a *BanksAPIService) GetBankProfileExecute(r ApiGetBankProfileRequest) (*Ba
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -897,6 +910,7 @@ func (a *BanksAPIService) GetBankProfileExecute(r ApiGetBankProfileRequest) (*Ba
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -996,6 +1010,7 @@ func (a *BanksAPIService) ListBanksExecute(r ApiListBanksRequest) (*BankListResp
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -1015,6 +1030,7 @@ func (a *BanksAPIService) ListBanksExecute(r ApiListBanksRequest) (*BankListResp
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -1118,6 +1134,7 @@ func (a *BanksAPIService) ResetBankConfigExecute(r ApiResetBankConfigRequest) (*
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -1137,6 +1154,7 @@ func (a *BanksAPIService) ResetBankConfigExecute(r ApiResetBankConfigRequest) (*
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -1240,6 +1258,7 @@ func (a *BanksAPIService) TriggerConsolidationExecute(r ApiTriggerConsolidationR
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -1259,6 +1278,7 @@ func (a *BanksAPIService) TriggerConsolidationExecute(r ApiTriggerConsolidationR
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -1373,6 +1393,7 @@ func (a *BanksAPIService) UpdateBankExecute(r ApiUpdateBankRequest) (*BankProfil
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -1392,6 +1413,7 @@ func (a *BanksAPIService) UpdateBankExecute(r ApiUpdateBankRequest) (*BankProfil
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -1506,6 +1528,7 @@ func (a *BanksAPIService) UpdateBankConfigExecute(r ApiUpdateBankConfigRequest)
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -1525,6 +1548,7 @@ func (a *BanksAPIService) UpdateBankConfigExecute(r ApiUpdateBankConfigRequest)
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -1642,6 +1666,7 @@ func (a *BanksAPIService) UpdateBankDispositionExecute(r ApiUpdateBankDispositio
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -1661,6 +1686,7 @@ func (a *BanksAPIService) UpdateBankDispositionExecute(r ApiUpdateBankDispositio
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -130,6 +130,7 @@ func (a *DirectivesAPIService) CreateDirectiveExecute(r ApiCreateDirectiveReques
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -149,6 +150,7 @@ func (a *DirectivesAPIService) CreateDirectiveExecute(r ApiCreateDirectiveReques
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -256,6 +258,7 @@ func (a *DirectivesAPIService) DeleteDirectiveExecute(r ApiDeleteDirectiveReques
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -275,6 +278,7 @@ func (a *DirectivesAPIService) DeleteDirectiveExecute(r ApiDeleteDirectiveReques
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -382,6 +386,7 @@ func (a *DirectivesAPIService) GetDirectiveExecute(r ApiGetDirectiveRequest) (*D
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -401,6 +406,7 @@ func (a *DirectivesAPIService) GetDirectiveExecute(r ApiGetDirectiveRequest) (*D
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -414,7 +420,7 @@ type ApiListDirectivesRequest struct {
 	ApiService *DirectivesAPIService
 	bankId string
 	tags *[]string
-	tagsMatch *string
+	tagsMatch *TagsMatch
 	activeOnly *bool
 	limit *int32
 	offset *int32
@@ -428,7 +434,7 @@ func (r ApiListDirectivesRequest) Tags(tags []string) ApiListDirectivesRequest {
 }
 
 // How to match tags
-func (r ApiListDirectivesRequest) TagsMatch(tagsMatch string) ApiListDirectivesRequest {
+func (r ApiListDirectivesRequest) TagsMatch(tagsMatch TagsMatch) ApiListDirectivesRequest {
 	r.tagsMatch = &tagsMatch
 	return r
 }
@@ -511,7 +517,7 @@ func (a *DirectivesAPIService) ListDirectivesExecute(r ApiListDirectivesRequest)
 	if r.tagsMatch != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "tags_match", r.tagsMatch, "form", "")
 	} else {
-		var defaultValue string = "any"
+		var defaultValue TagsMatch = "any"
 		r.tagsMatch = &defaultValue
 	}
 	if r.activeOnly != nil {
@@ -572,6 +578,7 @@ func (a *DirectivesAPIService) ListDirectivesExecute(r ApiListDirectivesRequest)
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -591,6 +598,7 @@ func (a *DirectivesAPIService) ListDirectivesExecute(r ApiListDirectivesRequest)
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -709,6 +717,7 @@ func (a *DirectivesAPIService) UpdateDirectiveExecute(r ApiUpdateDirectiveReques
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -728,6 +737,7 @@ func (a *DirectivesAPIService) UpdateDirectiveExecute(r ApiUpdateDirectiveReques
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -129,6 +129,7 @@ func (a *DocumentsAPIService) DeleteDocumentExecute(r ApiDeleteDocumentRequest)
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -148,6 +149,7 @@ func (a *DocumentsAPIService) DeleteDocumentExecute(r ApiDeleteDocumentRequest)
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -251,6 +253,7 @@ func (a *DocumentsAPIService) GetChunkExecute(r ApiGetChunkRequest) (*ChunkRespo
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -270,6 +273,7 @@ func (a *DocumentsAPIService) GetChunkExecute(r ApiGetChunkRequest) (*ChunkRespo
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -377,6 +381,7 @@ func (a *DocumentsAPIService) GetDocumentExecute(r ApiGetDocumentRequest) (*Docu
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -396,6 +401,7 @@ func (a *DocumentsAPIService) GetDocumentExecute(r ApiGetDocumentRequest) (*Docu
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -532,6 +538,7 @@ func (a *DocumentsAPIService) ListDocumentsExecute(r ApiListDocumentsRequest) (*
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -551,6 +558,7 @@ func (a *DocumentsAPIService) ListDocumentsExecute(r ApiListDocumentsRequest) (*
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
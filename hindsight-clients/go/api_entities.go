@@ -122,6 +122,7 @@ func (a *EntitiesAPIService) GetEntityExecute(r ApiGetEntityRequest) (*EntityDet
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -141,6 +142,7 @@ func (a *EntitiesAPIService) GetEntityExecute(r ApiGetEntityRequest) (*EntityDet
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -270,6 +272,7 @@ func (a *EntitiesAPIService) ListEntitiesExecute(r ApiListEntitiesRequest) (*Ent
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -289,6 +292,7 @@ func (a *EntitiesAPIService) ListEntitiesExecute(r ApiListEntitiesRequest) (*Ent
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -399,6 +403,7 @@ func (a *EntitiesAPIService) RegenerateEntityObservationsExecute(r ApiRegenerate
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -418,6 +423,7 @@ func (a *EntitiesAPIService) RegenerateEntityObservationsExecute(r ApiRegenerate
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
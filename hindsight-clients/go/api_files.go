@@ -181,6 +181,7 @@ func (a *FilesAPIService) FileRetainExecute(r ApiFileRetainRequest) (*FileRetain
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -200,6 +201,7 @@ func (a *FilesAPIService) FileRetainExecute(r ApiFileRetainRequest) (*FileRetain
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -130,6 +130,7 @@ func (a *MentalModelsAPIService) CreateMentalModelExecute(r ApiCreateMentalModel
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -149,6 +150,7 @@ func (a *MentalModelsAPIService) CreateMentalModelExecute(r ApiCreateMentalModel
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -256,6 +258,7 @@ func (a *MentalModelsAPIService) DeleteMentalModelExecute(r ApiDeleteMentalModel
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -275,6 +278,7 @@ func (a *MentalModelsAPIService) DeleteMentalModelExecute(r ApiDeleteMentalModel
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -382,6 +386,7 @@ func (a *MentalModelsAPIService) GetMentalModelExecute(r ApiGetMentalModelReques
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -401,6 +406,7 @@ func (a *MentalModelsAPIService) GetMentalModelExecute(r ApiGetMentalModelReques
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -414,7 +420,7 @@ type ApiListMentalModelsRequest struct {
 	ApiService *MentalModelsAPIService
 	bankId string
 	tags *[]string
-	tagsMatch *string
+	tagsMatch *TagsMatch
 	limit *int32
 	offset *int32
 	authorization *string
@@ -427,7 +433,7 @@ func (r ApiListMentalModelsRequest) Tags(tags []string) ApiListMentalModelsReque
 }
 
 // How to match tags
-func (r ApiListMentalModelsRequest) TagsMatch(tagsMatch string) ApiListMentalModelsRequest {
+func (r ApiListMentalModelsRequest) TagsMatch(tagsMatch TagsMatch) ApiListMentalModelsRequest {
 	r.tagsMatch = &tagsMatch
 	return r
 }
@@ -504,7 +510,7 @@ func (a *MentalModelsAPIService) ListMentalModelsExecute(r ApiListMentalModelsRe
 	if r.tagsMatch != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "tags_match", r.tagsMatch, "form", "")
 	} else {
-		var defaultValue string = "any"
+		var defaultValue TagsMatch = "any"
 		r.tagsMatch = &defaultValue
 	}
 	if r.limit != nil {
@@ -559,6 +565,7 @@ func (a *MentalModelsAPIService) ListMentalModelsExecute(r ApiListMentalModelsRe
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -578,6 +585,7 @@ func (a *MentalModelsAPIService) ListMentalModelsExecute(r ApiListMentalModelsRe
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -685,6 +693,7 @@ func (a *MentalModelsAPIService) RefreshMentalModelExecute(r ApiRefreshMentalMod
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -704,6 +713,7 @@ func (a *MentalModelsAPIService) RefreshMentalModelExecute(r ApiRefreshMentalMod
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -822,6 +832,7 @@ func (a *MentalModelsAPIService) UpdateMentalModelExecute(r ApiUpdateMentalModel
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -841,6 +852,7 @@ func (a *MentalModelsAPIService) UpdateMentalModelExecute(r ApiUpdateMentalModel
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
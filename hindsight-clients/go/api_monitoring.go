@@ -104,6 +104,7 @@ func (a *MonitoringAPIService) GetVersionExecute(r ApiGetVersionRequest) (*Versi
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -113,6 +114,7 @@ func (a *MonitoringAPIService) GetVersionExecute(r ApiGetVersionRequest) (*Versi
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -203,6 +205,7 @@ func (a *MonitoringAPIService) HealthEndpointHealthGetExecute(r ApiHealthEndpoin
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -212,6 +215,7 @@ func (a *MonitoringAPIService) HealthEndpointHealthGetExecute(r ApiHealthEndpoin
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -302,6 +306,7 @@ func (a *MonitoringAPIService) MetricsEndpointMetricsGetExecute(r ApiMetricsEndp
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -311,6 +316,7 @@ func (a *MonitoringAPIService) MetricsEndpointMetricsGetExecute(r ApiMetricsEndp
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -122,6 +122,7 @@ func (a *OperationsAPIService) CancelOperationExecute(r ApiCancelOperationReques
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -141,6 +142,7 @@ func (a *OperationsAPIService) CancelOperationExecute(r ApiCancelOperationReques
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -248,6 +250,7 @@ func (a *OperationsAPIService) GetOperationStatusExecute(r ApiGetOperationStatus
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -267,6 +270,7 @@ func (a *OperationsAPIService) GetOperationStatusExecute(r ApiGetOperationStatus
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -279,14 +283,14 @@ type ApiListOperationsRequest struct {
 	ctx context.Context
 	ApiService *OperationsAPIService
 	bankId string
-	status *string
+	status *OperationStatus
 	limit *int32
 	offset *int32
 	authorization *string
 }
 
 // Filter by status: pending, completed, or failed
-func (r ApiListOperationsRequest) Status(status string) ApiListOperationsRequest {
+func (r ApiListOperationsRequest) Status(status OperationStatus) ApiListOperationsRequest {
 	r.status = &status
 	return r
 }
@@ -406,6 +410,7 @@ func (a *OperationsAPIService) ListOperationsExecute(r ApiListOperationsRequest)
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 422 {
@@ -425,6 +430,7 @@ func (a *OperationsAPIService) ListOperationsExecute(r ApiListOperationsRequest)
 	if err != nil {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
+			requestID: ResponseRequestID(localVarHTTPResponse),
 			error: err.Error(),
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
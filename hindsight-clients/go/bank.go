@@ -0,0 +1,542 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors matched by *APIError with errors.Is, based on the HTTP
+// status of the failed response.
+var (
+	// ErrNotFound matches 404 responses.
+	ErrNotFound = errors.New("hindsight: not found")
+	// ErrUnauthorized matches 401 and 403 responses.
+	ErrUnauthorized = errors.New("hindsight: unauthorized")
+	// ErrInvalidRequest matches 400 and 422 responses.
+	ErrInvalidRequest = errors.New("hindsight: invalid request")
+)
+
+// APIError is the typed error returned by BankClient methods. It records
+// which operation failed and unwraps to the underlying client or transport
+// error, such as a *GenericOpenAPIError carrying the response body.
+type APIError struct {
+	// Operation is the API operation, for example "MemoryAPIService.RecallMemories".
+	Operation string
+	BankID    string
+	// StatusCode is the HTTP status of the response, or 0 if none was
+	// received.
+	StatusCode int
+	// RequestID is the X-Request-ID of the failed call; quote it when
+	// asking for the matching server logs.
+	RequestID string
+	Err       error
+}
+
+func (e *APIError) Error() string {
+	suffix := ""
+	if RequestIDFromError(e.Err) == "" {
+		suffix = requestIDSuffix(e.RequestID)
+	}
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("hindsight: %s (bank %s): HTTP %d: %v%s", e.Operation, e.BankID, e.StatusCode, e.Err, suffix)
+	}
+	return fmt.Sprintf("hindsight: %s (bank %s): %v%s", e.Operation, e.BankID, e.Err, suffix)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrNotFound, ErrUnauthorized and ErrInvalidRequest by status
+// code. ErrInvalidRequest also matches requests rejected by client-side
+// validation.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrInvalidRequest:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity ||
+			e.StatusCode == 0 && isValidationError(e.Err)
+	}
+	return false
+}
+
+// Body returns the response body of a failed request, if any.
+func (e *APIError) Body() []byte {
+	var apiErr *GenericOpenAPIError
+	if errors.As(e.Err, &apiErr) {
+		return apiErr.Body()
+	}
+	return nil
+}
+
+// BankClient is a view of an APIClient scoped to one memory bank, with
+// concise methods in place of the generated request builders.
+type BankClient struct {
+	client *APIClient
+	id     string
+}
+
+// Bank returns a BankClient for bankID. It shares the client's configuration
+// and transport.
+//
+// Example:
+//
+//	bank := client.Bank("agent-1")
+//	_, err := bank.Retain(ctx, *hindsight.NewMemoryItem("Alice prefers tea"))
+//	resp, err := bank.Recall(ctx, "What does Alice drink?", hindsight.RecallBudget(hindsight.HIGH))
+func (c *APIClient) Bank(bankID string) *BankClient {
+	return &BankClient{client: c, id: bankID}
+}
+
+// ID returns the bank ID the client is scoped to.
+func (b *BankClient) ID() string {
+	return b.id
+}
+
+func (b *BankClient) wrap(operation string, resp *http.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+	e := &APIError{Operation: operation, BankID: b.id, RequestID: ResponseRequestID(resp), Err: err}
+	if resp != nil {
+		e.StatusCode = resp.StatusCode
+	}
+	if e.RequestID == "" {
+		e.RequestID = RequestIDFromError(err)
+	}
+	return e
+}
+
+// Profile returns the bank's profile.
+func (b *BankClient) Profile(ctx context.Context) (*BankProfileResponse, error) {
+	out, resp, err := b.client.BanksAPI.GetBankProfile(ctx, b.id).Execute()
+	return out, b.wrap("BanksAPIService.GetBankProfile", resp, err)
+}
+
+// Stats returns memory and operation counts for the bank.
+func (b *BankClient) Stats(ctx context.Context) (*BankStatsResponse, error) {
+	out, resp, err := b.client.BanksAPI.GetAgentStats(ctx, b.id).Execute()
+	return out, b.wrap("BanksAPIService.GetAgentStats", resp, err)
+}
+
+// Retain stores items synchronously.
+func (b *BankClient) Retain(ctx context.Context, items ...MemoryItem) (*RetainResponse, error) {
+	return b.retain(ctx, false, items)
+}
+
+// RetainAsync submits items for background processing. Track the returned
+// operation with Operations().
+func (b *BankClient) RetainAsync(ctx context.Context, items ...MemoryItem) (*RetainResponse, error) {
+	return b.retain(ctx, true, items)
+}
+
+func (b *BankClient) retain(ctx context.Context, async bool, items []MemoryItem) (*RetainResponse, error) {
+	req := NewRetainRequest(items)
+	if async {
+		req.SetAsync(true)
+	}
+	out, resp, err := b.client.MemoryAPI.RetainMemories(ctx, b.id).RetainRequest(*req).Execute()
+	return out, b.wrap("MemoryAPIService.RetainMemories", resp, err)
+}
+
+// Clear deletes memories from the bank. An empty factType deletes all of
+// them; otherwise only memories of that type are removed.
+func (b *BankClient) Clear(ctx context.Context, factType FactType) (*DeleteResponse, error) {
+	r := b.client.MemoryAPI.ClearBankMemories(ctx, b.id)
+	if factType != "" {
+		r = r.Type_(factType)
+	}
+	out, resp, err := r.Execute()
+	return out, b.wrap("MemoryAPIService.ClearBankMemories", resp, err)
+}
+
+// RecallOption customises a Recall call.
+type RecallOption func(*RecallRequest)
+
+// RecallTypes restricts recall to the given fact types.
+func RecallTypes(types ...FactType) RecallOption {
+	return func(r *RecallRequest) { r.SetTypes(types) }
+}
+
+// RecallBudget sets the search budget.
+func RecallBudget(budget Budget) RecallOption {
+	return func(r *RecallRequest) { r.SetBudget(budget) }
+}
+
+// RecallMaxTokens caps the size of the returned facts.
+func RecallMaxTokens(n int32) RecallOption {
+	return func(r *RecallRequest) { r.SetMaxTokens(n) }
+}
+
+// RecallTags filters results by tags, matched as match says; empty uses the
+// server default.
+func RecallTags(match TagsMatch, tags ...string) RecallOption {
+	return func(r *RecallRequest) {
+		r.SetTags(tags)
+		if match != "" {
+			r.SetTagsMatch(match)
+		}
+	}
+}
+
+// RecallAt recalls as of the given point in time.
+func RecallAt(t time.Time) RecallOption {
+	return func(r *RecallRequest) { r.SetQueryTime(t) }
+}
+
+// RecallTrace asks the server to include a trace of the search.
+func RecallTrace() RecallOption {
+	return func(r *RecallRequest) { r.SetTrace(true) }
+}
+
+// RecallEntities includes entity observations, up to maxTokens.
+func RecallEntities(maxTokens int32) RecallOption {
+	return func(r *RecallRequest) {
+		include := r.GetInclude()
+		opts := NewEntityIncludeOptions()
+		opts.SetMaxTokens(maxTokens)
+		include.SetEntities(*opts)
+		r.SetInclude(include)
+	}
+}
+
+// RecallChunks includes the source chunks of results, up to maxTokens.
+func RecallChunks(maxTokens int32) RecallOption {
+	return func(r *RecallRequest) {
+		include := r.GetInclude()
+		opts := NewChunkIncludeOptions()
+		opts.SetMaxTokens(maxTokens)
+		include.SetChunks(*opts)
+		r.SetInclude(include)
+	}
+}
+
+// Recall searches the bank for memories relevant to query.
+func (b *BankClient) Recall(ctx context.Context, query string, opts ...RecallOption) (*RecallResponse, error) {
+	req := NewRecallRequest(query)
+	for _, opt := range opts {
+		opt(req)
+	}
+	out, resp, err := b.client.MemoryAPI.RecallMemories(ctx, b.id).RecallRequest(*req).Execute()
+	return out, b.wrap("MemoryAPIService.RecallMemories", resp, err)
+}
+
+// ReflectOption customises a Reflect call.
+type ReflectOption func(*ReflectRequest)
+
+// ReflectBudget sets the search budget.
+func ReflectBudget(budget Budget) ReflectOption {
+	return func(r *ReflectRequest) { r.SetBudget(budget) }
+}
+
+// ReflectContext adds context for the question.
+func ReflectContext(context string) ReflectOption {
+	return func(r *ReflectRequest) { r.SetContext(context) }
+}
+
+// ReflectMaxTokens caps the length of the answer.
+func ReflectMaxTokens(n int32) ReflectOption {
+	return func(r *ReflectRequest) { r.SetMaxTokens(n) }
+}
+
+// ReflectTags filters the memories considered by tags. match is as for
+// RecallTags.
+func ReflectTags(match TagsMatch, tags ...string) ReflectOption {
+	return func(r *ReflectRequest) {
+		r.SetTags(tags)
+		if match != "" {
+			r.SetTagsMatch(match)
+		}
+	}
+}
+
+// ReflectSchema requests a structured answer matching the JSON schema.
+func ReflectSchema(schema map[string]interface{}) ReflectOption {
+	return func(r *ReflectRequest) { r.SetResponseSchema(schema) }
+}
+
+// Reflect answers query using the bank's memories.
+func (b *BankClient) Reflect(ctx context.Context, query string, opts ...ReflectOption) (*ReflectResponse, error) {
+	req := NewReflectRequest(query)
+	for _, opt := range opts {
+		opt(req)
+	}
+	out, resp, err := b.client.MemoryAPI.Reflect(ctx, b.id).ReflectRequest(*req).Execute()
+	return out, b.wrap("MemoryAPIService.Reflect", resp, err)
+}
+
+// ListOption sets paging and filtering on list calls. Options that do not
+// apply to a given list are ignored.
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	limit, offset *int32
+	query         string
+	tags          []string
+	tagsMatch     TagsMatch
+	status        OperationStatus
+}
+
+// ListLimit sets the maximum number of items returned.
+func ListLimit(n int32) ListOption {
+	return func(o *listOptions) { o.limit = &n }
+}
+
+// ListOffset skips the first n items.
+func ListOffset(n int32) ListOption {
+	return func(o *listOptions) { o.offset = &n }
+}
+
+// ListQuery filters documents by a search string.
+func ListQuery(q string) ListOption {
+	return func(o *listOptions) { o.query = q }
+}
+
+// ListTags filters directives and mental models by tags. match is as for
+// RecallTags.
+func ListTags(match TagsMatch, tags ...string) ListOption {
+	return func(o *listOptions) {
+		o.tags = tags
+		o.tagsMatch = match
+	}
+}
+
+// ListStatus filters operations by status.
+func ListStatus(status OperationStatus) ListOption {
+	return func(o *listOptions) { o.status = status }
+}
+
+func applyListOptions(opts []ListOption) listOptions {
+	var o listOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Documents returns the bank's document operations.
+func (b *BankClient) Documents() *DocumentsClient {
+	return &DocumentsClient{b}
+}
+
+// DocumentsClient manages the documents of one bank.
+type DocumentsClient struct {
+	bank *BankClient
+}
+
+// List lists documents. It honours ListQuery, ListLimit and ListOffset.
+func (d *DocumentsClient) List(ctx context.Context, opts ...ListOption) (*ListDocumentsResponse, error) {
+	o := applyListOptions(opts)
+	r := d.bank.client.DocumentsAPI.ListDocuments(ctx, d.bank.id)
+	if o.query != "" {
+		r = r.Q(o.query)
+	}
+	if o.limit != nil {
+		r = r.Limit(*o.limit)
+	}
+	if o.offset != nil {
+		r = r.Offset(*o.offset)
+	}
+	out, resp, err := r.Execute()
+	return out, d.bank.wrap("DocumentsAPIService.ListDocuments", resp, err)
+}
+
+// Get returns a document.
+func (d *DocumentsClient) Get(ctx context.Context, documentID string) (*DocumentResponse, error) {
+	out, resp, err := d.bank.client.DocumentsAPI.GetDocument(ctx, d.bank.id, documentID).Execute()
+	return out, d.bank.wrap("DocumentsAPIService.GetDocument", resp, err)
+}
+
+// Delete deletes a document and the memories extracted from it.
+func (d *DocumentsClient) Delete(ctx context.Context, documentID string) (*DeleteDocumentResponse, error) {
+	out, resp, err := d.bank.client.DocumentsAPI.DeleteDocument(ctx, d.bank.id, documentID).Execute()
+	return out, d.bank.wrap("DocumentsAPIService.DeleteDocument", resp, err)
+}
+
+// Directives returns the bank's directive operations.
+func (b *BankClient) Directives() *DirectivesClient {
+	return &DirectivesClient{b}
+}
+
+// DirectivesClient manages the directives of one bank.
+type DirectivesClient struct {
+	bank *BankClient
+}
+
+// List lists directives. It honours ListTags, ListLimit and ListOffset.
+func (d *DirectivesClient) List(ctx context.Context, opts ...ListOption) (*DirectiveListResponse, error) {
+	o := applyListOptions(opts)
+	r := d.bank.client.DirectivesAPI.ListDirectives(ctx, d.bank.id)
+	if len(o.tags) > 0 {
+		r = r.Tags(o.tags)
+	}
+	if o.tagsMatch != "" {
+		r = r.TagsMatch(o.tagsMatch)
+	}
+	if o.limit != nil {
+		r = r.Limit(*o.limit)
+	}
+	if o.offset != nil {
+		r = r.Offset(*o.offset)
+	}
+	out, resp, err := r.Execute()
+	return out, d.bank.wrap("DirectivesAPIService.ListDirectives", resp, err)
+}
+
+// Get returns a directive.
+func (d *DirectivesClient) Get(ctx context.Context, directiveID string) (*DirectiveResponse, error) {
+	out, resp, err := d.bank.client.DirectivesAPI.GetDirective(ctx, d.bank.id, directiveID).Execute()
+	return out, d.bank.wrap("DirectivesAPIService.GetDirective", resp, err)
+}
+
+// Create creates a directive.
+func (d *DirectivesClient) Create(ctx context.Context, req CreateDirectiveRequest) (*DirectiveResponse, error) {
+	out, resp, err := d.bank.client.DirectivesAPI.CreateDirective(ctx, d.bank.id).CreateDirectiveRequest(req).Execute()
+	return out, d.bank.wrap("DirectivesAPIService.CreateDirective", resp, err)
+}
+
+// Update updates a directive.
+func (d *DirectivesClient) Update(ctx context.Context, directiveID string, req UpdateDirectiveRequest) (*DirectiveResponse, error) {
+	out, resp, err := d.bank.client.DirectivesAPI.UpdateDirective(ctx, d.bank.id, directiveID).UpdateDirectiveRequest(req).Execute()
+	return out, d.bank.wrap("DirectivesAPIService.UpdateDirective", resp, err)
+}
+
+// Delete deletes a directive.
+func (d *DirectivesClient) Delete(ctx context.Context, directiveID string) error {
+	_, resp, err := d.bank.client.DirectivesAPI.DeleteDirective(ctx, d.bank.id, directiveID).Execute()
+	return d.bank.wrap("DirectivesAPIService.DeleteDirective", resp, err)
+}
+
+// MentalModels returns the bank's mental model operations.
+func (b *BankClient) MentalModels() *MentalModelsClient {
+	return &MentalModelsClient{b}
+}
+
+// MentalModelsClient manages the mental models of one bank.
+type MentalModelsClient struct {
+	bank *BankClient
+}
+
+// List lists mental models. It honours ListTags, ListLimit and ListOffset.
+func (m *MentalModelsClient) List(ctx context.Context, opts ...ListOption) (*MentalModelListResponse, error) {
+	o := applyListOptions(opts)
+	r := m.bank.client.MentalModelsAPI.ListMentalModels(ctx, m.bank.id)
+	if len(o.tags) > 0 {
+		r = r.Tags(o.tags)
+	}
+	if o.tagsMatch != "" {
+		r = r.TagsMatch(o.tagsMatch)
+	}
+	if o.limit != nil {
+		r = r.Limit(*o.limit)
+	}
+	if o.offset != nil {
+		r = r.Offset(*o.offset)
+	}
+	out, resp, err := r.Execute()
+	return out, m.bank.wrap("MentalModelsAPIService.ListMentalModels", resp, err)
+}
+
+// Get returns a mental model.
+func (m *MentalModelsClient) Get(ctx context.Context, mentalModelID string) (*MentalModelResponse, error) {
+	out, resp, err := m.bank.client.MentalModelsAPI.GetMentalModel(ctx, m.bank.id, mentalModelID).Execute()
+	return out, m.bank.wrap("MentalModelsAPIService.GetMentalModel", resp, err)
+}
+
+// Create creates a mental model. Its content is generated in the background.
+func (m *MentalModelsClient) Create(ctx context.Context, req CreateMentalModelRequest) (*CreateMentalModelResponse, error) {
+	out, resp, err := m.bank.client.MentalModelsAPI.CreateMentalModel(ctx, m.bank.id).CreateMentalModelRequest(req).Execute()
+	return out, m.bank.wrap("MentalModelsAPIService.CreateMentalModel", resp, err)
+}
+
+// Update updates a mental model.
+func (m *MentalModelsClient) Update(ctx context.Context, mentalModelID string, req UpdateMentalModelRequest) (*MentalModelResponse, error) {
+	out, resp, err := m.bank.client.MentalModelsAPI.UpdateMentalModel(ctx, m.bank.id, mentalModelID).UpdateMentalModelRequest(req).Execute()
+	return out, m.bank.wrap("MentalModelsAPIService.UpdateMentalModel", resp, err)
+}
+
+// Refresh regenerates a mental model in the background.
+func (m *MentalModelsClient) Refresh(ctx context.Context, mentalModelID string) (*AsyncOperationSubmitResponse, error) {
+	out, resp, err := m.bank.client.MentalModelsAPI.RefreshMentalModel(ctx, m.bank.id, mentalModelID).Execute()
+	return out, m.bank.wrap("MentalModelsAPIService.RefreshMentalModel", resp, err)
+}
+
+// Delete deletes a mental model.
+func (m *MentalModelsClient) Delete(ctx context.Context, mentalModelID string) error {
+	_, resp, err := m.bank.client.MentalModelsAPI.DeleteMentalModel(ctx, m.bank.id, mentalModelID).Execute()
+	return m.bank.wrap("MentalModelsAPIService.DeleteMentalModel", resp, err)
+}
+
+// Operations returns the bank's async operation tracking.
+func (b *BankClient) Operations() *OperationsClient {
+	return &OperationsClient{b}
+}
+
+// OperationsClient tracks the async operations of one bank.
+type OperationsClient struct {
+	bank *BankClient
+}
+
+// List lists operations. It honours ListStatus, ListLimit and ListOffset.
+func (o *OperationsClient) List(ctx context.Context, opts ...ListOption) (*OperationsListResponse, error) {
+	lo := applyListOptions(opts)
+	r := o.bank.client.OperationsAPI.ListOperations(ctx, o.bank.id)
+	if lo.status != "" {
+		r = r.Status(lo.status)
+	}
+	if lo.limit != nil {
+		r = r.Limit(*lo.limit)
+	}
+	if lo.offset != nil {
+		r = r.Offset(*lo.offset)
+	}
+	out, resp, err := r.Execute()
+	return out, o.bank.wrap("OperationsAPIService.ListOperations", resp, err)
+}
+
+// Get returns the status of an operation.
+func (o *OperationsClient) Get(ctx context.Context, operationID string) (*OperationStatusResponse, error) {
+	out, resp, err := o.bank.client.OperationsAPI.GetOperationStatus(ctx, o.bank.id, operationID).Execute()
+	return out, o.bank.wrap("OperationsAPIService.GetOperationStatus", resp, err)
+}
+
+// Cancel cancels a pending operation.
+func (o *OperationsClient) Cancel(ctx context.Context, operationID string) (*CancelOperationResponse, error) {
+	out, resp, err := o.bank.client.OperationsAPI.CancelOperation(ctx, o.bank.id, operationID).Execute()
+	return out, o.bank.wrap("OperationsAPIService.CancelOperation", resp, err)
+}
+
+// Config returns the bank's configuration overrides.
+func (b *BankClient) Config() *ConfigClient {
+	return &ConfigClient{b}
+}
+
+// ConfigClient reads and changes the configuration overrides of one bank.
+type ConfigClient struct {
+	bank *BankClient
+}
+
+// Get returns the resolved configuration and the bank's overrides.
+func (c *ConfigClient) Get(ctx context.Context) (*BankConfigResponse, error) {
+	out, resp, err := c.bank.client.BanksAPI.GetBankConfig(ctx, c.bank.id).Execute()
+	return out, c.bank.wrap("BanksAPIService.GetBankConfig", resp, err)
+}
+
+// Update sets configuration overrides for the bank. The keys are sent as
+// given; Set takes a typed BankConfig instead.
+func (c *ConfigClient) Update(ctx context.Context, updates map[string]interface{}) (*BankConfigResponse, error) {
+	out, resp, err := c.bank.client.BanksAPI.UpdateBankConfig(ctx, c.bank.id).BankConfigUpdate(*NewBankConfigUpdate(updates)).Execute()
+	return out, c.bank.wrap("BanksAPIService.UpdateBankConfig", resp, err)
+}
+
+// Reset removes all of the bank's configuration overrides.
+func (c *ConfigClient) Reset(ctx context.Context) (*BankConfigResponse, error) {
+	out, resp, err := c.bank.client.BanksAPI.ResetBankConfig(ctx, c.bank.id).Execute()
+	return out, c.bank.wrap("BanksAPIService.ResetBankConfig", resp, err)
+}
@@ -0,0 +1,79 @@
+package hindsight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBankClient(t *testing.T) {
+	var lastBody map[string]interface{}
+	var lastQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		lastBody = nil
+		json.Unmarshal(b, &lastBody)
+		lastQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/default/banks/agent/memories":
+			w.Write([]byte(`{"success":true,"bank_id":"agent","items_count":2,"async":false}`))
+		case "POST /v1/default/banks/agent/memories/recall":
+			w.Write([]byte(`{"results":[{"id":"m1","text":"Alice prefers tea"}]}`))
+		case "GET /v1/default/banks/agent/documents":
+			w.Write([]byte(`{"items":[],"total":0,"limit":5,"offset":10}`))
+		case "GET /v1/default/banks/agent/documents/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail":"Document not found"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusTeapot)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	bank := NewAPIClient(cfg).Bank("agent")
+	ctx := context.Background()
+
+	retained, err := bank.Retain(ctx, *NewMemoryItem("Alice prefers tea"), *NewMemoryItem("Bob prefers coffee"))
+	if err != nil || retained.ItemsCount != 2 {
+		t.Fatalf("Retain = %+v, %v", retained, err)
+	}
+	if items := lastBody["items"].([]interface{}); len(items) != 2 {
+		t.Fatalf("sent %d items", len(items))
+	}
+
+	recalled, err := bank.Recall(ctx, "What does Alice drink?",
+		RecallBudget(HIGH), RecallTypes("world"), RecallTags("all", "food"), RecallEntities(100))
+	if err != nil || len(recalled.Results) != 1 {
+		t.Fatalf("Recall = %+v, %v", recalled, err)
+	}
+	if lastBody["budget"] != "high" || lastBody["tags_match"] != "all" || lastBody["include"].(map[string]interface{})["entities"] == nil {
+		t.Fatalf("recall options not applied: %v", lastBody)
+	}
+
+	if _, err := bank.Documents().List(ctx, ListLimit(5), ListOffset(10), ListQuery("notes")); err != nil {
+		t.Fatal(err)
+	}
+	if lastQuery != "limit=5&offset=10&q=notes" {
+		t.Fatalf("query = %q", lastQuery)
+	}
+
+	_, err = bank.Documents().Get(ctx, "missing")
+	var apiErr *APIError
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &apiErr) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if apiErr.Operation != "DocumentsAPIService.GetDocument" || apiErr.BankID != "agent" || string(apiErr.Body()) != `{"detail":"Document not found"}` {
+		t.Fatalf("unexpected error %+v", apiErr)
+	}
+	if errors.Is(err, ErrUnauthorized) {
+		t.Fatal("404 must not match ErrUnauthorized")
+	}
+}
@@ -0,0 +1,216 @@
+package hindsight
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// BankConfig is a bank's configuration: the keys the server allows to be
+// overridden per bank, as in BankConfigResponse's Config and Overrides maps.
+// A field that is not set is left out of the JSON, so an update sends only
+// the fields that were set and leaves the others unchanged.
+//
+// Fields the server allows to be null are Nullables, where null is sent as
+// an explicit null override (for MCPEnabledTools, null enables every tool).
+// The others are Optionals, which cannot be null. Keys this client does not
+// know, such as ones added by a newer server, are kept in Unknown.
+//
+// Example:
+//
+//	var cfg hindsight.BankConfig
+//	cfg.RetainChunkSize = hindsight.Some[int32](2000)
+//	cfg.ReflectMission = hindsight.NullableOf("Answer as a support agent")
+//	_, err := client.Bank("agent-1").Config().Set(ctx, cfg)
+type BankConfig struct {
+	// MCPEnabledTools lists the MCP tools the bank exposes; null exposes
+	// all of them.
+	MCPEnabledTools          Nullable[[]string]
+	RetainChunkSize          Optional[int32]
+	RetainExtractionMode     Optional[RetainExtractionMode]
+	RetainMission            NullableString
+	RetainCustomInstructions NullableString
+	EnableObservations       Optional[bool]
+	ObservationsMission      NullableString
+	ReflectMission           NullableString
+	DispositionSkepticism    NullableInt32
+	DispositionLiteralism    NullableInt32
+	DispositionEmpathy       NullableInt32
+
+	// Unknown holds the keys not listed above, with their JSON values.
+	Unknown map[string]interface{}
+}
+
+// bankConfigField is a BankConfig field, a Nullable or an Optional, and its
+// JSON key.
+type bankConfigField struct {
+	key   string
+	field interface {
+		IsSet() bool
+		json.Marshaler
+		json.Unmarshaler
+	}
+}
+
+// fields returns c's fields in the order of the server's list of
+// configurable fields in config.py.
+func (c *BankConfig) fields() []bankConfigField {
+	return []bankConfigField{
+		{"mcp_enabled_tools", &c.MCPEnabledTools},
+		{"retain_chunk_size", &c.RetainChunkSize},
+		{"retain_extraction_mode", &c.RetainExtractionMode},
+		{"retain_mission", &c.RetainMission},
+		{"retain_custom_instructions", &c.RetainCustomInstructions},
+		{"enable_observations", &c.EnableObservations},
+		{"observations_mission", &c.ObservationsMission},
+		{"reflect_mission", &c.ReflectMission},
+		{"disposition_skepticism", &c.DispositionSkepticism},
+		{"disposition_literalism", &c.DispositionLiteralism},
+		{"disposition_empathy", &c.DispositionEmpathy},
+	}
+}
+
+// ParseBankConfig converts a configuration map, such as
+// BankConfigResponse.Config, to a BankConfig. It fails if a known key has a
+// value of the wrong type.
+func ParseBankConfig(m map[string]interface{}) (BankConfig, error) {
+	var c BankConfig
+	data, err := json.Marshal(m)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
+// Map returns the set fields and the Unknown keys as a map, as sent by
+// ConfigClient.Update.
+func (c BankConfig) Map() (map[string]interface{}, error) {
+	m := make(map[string]interface{}, len(c.Unknown))
+	for k, v := range c.Unknown {
+		m[k] = v
+	}
+	for _, f := range c.fields() {
+		if !f.field.IsSet() {
+			continue
+		}
+		data, err := f.field.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("hindsight: bank config %s: %w", f.key, err)
+		}
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		m[f.key] = value
+	}
+	return m, nil
+}
+
+// MarshalJSON encodes the set fields and the Unknown keys as an object.
+func (c BankConfig) MarshalJSON() ([]byte, error) {
+	m, err := c.Map()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes an object, keeping unknown keys in Unknown.
+func (c *BankConfig) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*c = BankConfig{}
+	for _, f := range c.fields() {
+		v, ok := raw[f.key]
+		if !ok {
+			continue
+		}
+		if err := f.field.UnmarshalJSON(v); err != nil {
+			return fmt.Errorf("hindsight: bank config %s: %w", f.key, err)
+		}
+		delete(raw, f.key)
+	}
+	for k, v := range raw {
+		var value interface{}
+		if err := json.Unmarshal(v, &value); err != nil {
+			return err
+		}
+		if c.Unknown == nil {
+			c.Unknown = make(map[string]interface{}, len(raw))
+		}
+		c.Unknown[k] = value
+	}
+	return nil
+}
+
+// DiffBankConfig returns the fields and Unknown keys of to that are not set
+// to the same value in from. Diffing the effective configuration against
+// the desired one gives the smallest update that applies it; diffing the
+// bank's overrides against the effective configuration gives the settings
+// the bank inherits from its tenant or the server.
+func DiffBankConfig(from, to BankConfig) (BankConfig, error) {
+	var diff BankConfig
+	fromFields, diffFields := from.fields(), diff.fields()
+	for i, f := range to.fields() {
+		if !f.field.IsSet() {
+			continue
+		}
+		want, err := f.field.MarshalJSON()
+		if err != nil {
+			return diff, fmt.Errorf("hindsight: bank config %s: %w", f.key, err)
+		}
+		if fromFields[i].field.IsSet() {
+			have, err := fromFields[i].field.MarshalJSON()
+			if err != nil {
+				return diff, fmt.Errorf("hindsight: bank config %s: %w", f.key, err)
+			}
+			if bytes.Equal(have, want) {
+				continue
+			}
+		}
+		if err := diffFields[i].field.UnmarshalJSON(want); err != nil {
+			return diff, err
+		}
+	}
+	for k, v := range to.Unknown {
+		if old, ok := from.Unknown[k]; ok {
+			have, err1 := json.Marshal(old)
+			want, err2 := json.Marshal(v)
+			if err1 == nil && err2 == nil && bytes.Equal(have, want) {
+				continue
+			}
+		}
+		if diff.Unknown == nil {
+			diff.Unknown = make(map[string]interface{})
+		}
+		diff.Unknown[k] = v
+	}
+	return diff, nil
+}
+
+// EffectiveConfig returns Config, the configuration with all overrides
+// applied, as a BankConfig.
+func (o *BankConfigResponse) EffectiveConfig() (BankConfig, error) {
+	return ParseBankConfig(o.GetConfig())
+}
+
+// OverrideConfig returns Overrides, the bank's own overrides, as a
+// BankConfig.
+func (o *BankConfigResponse) OverrideConfig() (BankConfig, error) {
+	return ParseBankConfig(o.GetOverrides())
+}
+
+// Set sets the configuration overrides for the fields of cfg that are set.
+// Unlike Update it cannot send a misspelled key, except through
+// cfg.Unknown.
+func (c *ConfigClient) Set(ctx context.Context, cfg BankConfig) (*BankConfigResponse, error) {
+	updates, err := cfg.Map()
+	if err != nil {
+		return nil, err
+	}
+	return c.Update(ctx, updates)
+}
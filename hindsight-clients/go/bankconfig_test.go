@@ -0,0 +1,90 @@
+package hindsight
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBankConfig(t *testing.T) {
+	var lastBody map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		lastBody = nil
+		json.Unmarshal(b, &lastBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"bank_id":"agent","config":{
+			"mcp_enabled_tools":null,"retain_chunk_size":3000,"retain_extraction_mode":"concise",
+			"retain_mission":null,"enable_observations":true,"reflect_mission":"Be brief",
+			"disposition_empathy":3,"future_key":{"a":1}
+		},"overrides":{"reflect_mission":"Be brief","future_key":{"a":1}}}`))
+	}))
+	defer srv.Close()
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	config := NewAPIClient(cfg).Bank("agent").Config()
+	ctx := context.Background()
+
+	resp, err := config.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	effective, err := resp.EffectiveConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if effective.RetainChunkSize.ValueOr(0) != 3000 || effective.RetainExtractionMode.ValueOr("") != RetainExtractionModeConcise ||
+		!effective.MCPEnabledTools.IsNull() || effective.DispositionSkepticism.IsSet() {
+		t.Fatalf("effective = %+v", effective)
+	}
+	if _, ok := effective.Unknown["future_key"]; !ok || len(effective.Unknown) != 1 {
+		t.Fatalf("unknown = %v", effective.Unknown)
+	}
+
+	// Only the fields that were set are sent, unknown keys included.
+	var update BankConfig
+	update.RetainChunkSize = Some[int32](2000)
+	update.RetainMission = Null[string]()
+	update.Unknown = map[string]interface{}{"future_key": 2}
+	if _, err := config.Set(ctx, update); err != nil {
+		t.Fatal(err)
+	}
+	updates, _ := json.Marshal(lastBody["updates"])
+	if string(updates) != `{"future_key":2,"retain_chunk_size":2000,"retain_mission":null}` {
+		t.Fatalf("updates = %s", updates)
+	}
+
+	// Diffing keeps the changed fields and unknown keys only.
+	want := effective
+	want.RetainChunkSize = Some[int32](2000)
+	want.ReflectMission = NullableOf("Be brief")
+	want.DispositionLiteralism = NullableOf[int32](4)
+	want.Unknown = map[string]interface{}{"future_key": map[string]interface{}{"a": 1.0}, "newer_key": "x"}
+	diff, err := DiffBankConfig(effective, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal(diff)
+	if string(got) != `{"disposition_literalism":4,"newer_key":"x","retain_chunk_size":2000}` {
+		t.Fatalf("diff = %s", got)
+	}
+
+	// The settings not overridden by the bank are inherited.
+	overrides, err := resp.OverrideConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, _ = DiffBankConfig(overrides, effective)
+	got, _ = json.Marshal(diff)
+	if string(got) != `{"disposition_empathy":3,"enable_observations":true,"mcp_enabled_tools":null,"retain_chunk_size":3000,"retain_extraction_mode":"concise","retain_mission":null}` {
+		t.Fatalf("inherited = %s", got)
+	}
+
+	if _, err := ParseBankConfig(map[string]interface{}{"retain_chunk_size": "big"}); err == nil {
+		t.Fatal("ParseBankConfig accepted a string chunk size")
+	}
+}
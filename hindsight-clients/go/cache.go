@@ -0,0 +1,467 @@
+package hindsight
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCacheConfig configures a ResponseCache. Zero values select the
+// documented defaults.
+type ResponseCacheConfig struct {
+	// MaxEntries bounds the number of cached responses; the least recently
+	// used entry is evicted first. Defaults to 256.
+	MaxEntries int
+	// TTL is how long a response is cached when the server sends no
+	// Cache-Control or Expires header. Defaults to 30 seconds.
+	TTL time.Duration
+	// MaxEntryBytes is the largest response body that is cached. Defaults to
+	// 1 MiB.
+	MaxEntryBytes int64
+}
+
+// ResponseCacheStats reports a ResponseCache's activity.
+type ResponseCacheStats struct {
+	Hits    int64
+	Misses  int64
+	Entries int
+}
+
+// ResponseCache is an in-process LRU cache for recall responses and the
+// read-only GET endpoints. Entries are keyed on the normalized request:
+// method, scheme, host, path, sorted query, credentials and, for recall,
+// the JSON body with its keys and its types and tags lists sorted. The
+// credentials are the Authorization header and any per-call
+// Authorization(...) override, so clients with different servers or keys
+// can share a cache.
+//
+// Writes made through a client using the cache (any POST other than recall,
+// and PUT, PATCH and DELETE) invalidate the entries of the bank they target,
+// along with bank-less reads such as the bank list: clearing memories,
+// deleting documents and any other change empty the bank's entries. A
+// retain leaves a cached recall in place only if the recall filtered with
+// any_strict or all_strict tags that none of the retained items can match.
+// Writes by other clients are only seen once entries expire; use
+// InvalidateBank after them.
+//
+// When a response carries Cache-Control or Expires headers, its lifetime is
+// taken from CacheExpires; "no-store" and "no-cache" responses are not
+// cached. Only 200 responses are cached.
+type ResponseCache struct {
+	cfg ResponseCacheConfig
+	now func() time.Time
+
+	mu      sync.Mutex
+	lru     *list.List // of *cacheEntry, most recently used first
+	entries map[string]*list.Element
+	banks   map[string]map[string]struct{} // bank key -> entry keys
+	gens    map[string]uint64              // bank key -> invalidation count
+	idGens  map[string]uint64              // bank ID -> InvalidateBank count
+	hits    int64
+	misses  int64
+}
+
+type cacheEntry struct {
+	key     string
+	bank    string
+	bankID  string
+	filter  *strictTagFilter
+	status  string
+	code    int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// strictTagFilter is the tag filter of a cached recall that excludes
+// untagged memories.
+type strictTagFilter struct {
+	all  bool
+	tags []string
+}
+
+// NewResponseCache creates a ResponseCache. Install it with
+// Configuration.Use(c.Middleware()), outside any retry middleware and before
+// SetTokenSource, so that requests carry their credential when they are
+// keyed. A token source installed inside the cache fails every request it
+// would authorize.
+func NewResponseCache(cfg ResponseCacheConfig) *ResponseCache {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = 256
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * time.Second
+	}
+	if cfg.MaxEntryBytes <= 0 {
+		cfg.MaxEntryBytes = 1 << 20
+	}
+	return &ResponseCache{
+		cfg:     cfg,
+		now:     time.Now,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		banks:   make(map[string]map[string]struct{}),
+		gens:    make(map[string]uint64),
+		idGens:  make(map[string]uint64),
+	}
+}
+
+// Middleware returns the middleware serving and filling the cache.
+func (c *ResponseCache) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			op := OperationForRequest(req)
+			bank := op.Tenant + "/" + op.BankID
+			switch {
+			case req.Method == http.MethodPost && op.Class != OperationClassRecall,
+				req.Method == http.MethodPut, req.Method == http.MethodPatch, req.Method == http.MethodDelete:
+				return c.write(next, req, op, bank)
+			case op.Tenant == "" || req.Method != http.MethodGet && op.Class != OperationClassRecall:
+				// Health, metrics and other endpoints outside /v1 stay live.
+				return next.RoundTrip(req)
+			}
+			key, filter, ok := cacheKey(req)
+			if !ok {
+				return next.RoundTrip(req)
+			}
+			req = markCredentialKeyed(req)
+			if resp := c.get(key, req); resp != nil {
+				return resp, nil
+			}
+
+			c.mu.Lock()
+			gen := cacheGen{bank: c.gens[bank], id: c.idGens[op.BankID]}
+			c.mu.Unlock()
+			resp, err := next.RoundTrip(req)
+			if err != nil || resp.StatusCode != http.StatusOK {
+				return resp, err
+			}
+			expires, ok := c.expiry(resp)
+			if !ok || resp.ContentLength > c.cfg.MaxEntryBytes {
+				return resp, nil
+			}
+			body, err := io.ReadAll(io.LimitReader(resp.Body, c.cfg.MaxEntryBytes+1))
+			if err != nil || int64(len(body)) > c.cfg.MaxEntryBytes {
+				// Hand back what was read followed by the rest, uncached.
+				resp.Body = readCloser{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+				return resp, nil
+			}
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			c.put(&cacheEntry{
+				key:     key,
+				bank:    bank,
+				bankID:  op.BankID,
+				filter:  filter,
+				status:  resp.Status,
+				code:    resp.StatusCode,
+				header:  resp.Header.Clone(),
+				body:    body,
+				expires: expires,
+			}, gen)
+			return resp, nil
+		})
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// write forwards a request that may change server state and invalidates
+// the bank's entries both before it is sent and once it completes, so that
+// reads racing with it are not cached either.
+func (c *ResponseCache) write(next http.RoundTripper, req *http.Request, op Operation, bank string) (*http.Response, error) {
+	invalidate := func() { c.invalidate(bank, nil) }
+	if op.Name == "MemoryAPIService.RetainMemories" {
+		if tags, ok := retainedTags(req); ok {
+			invalidate = func() { c.invalidate(bank, tags) }
+		}
+	}
+	invalidate()
+	resp, err := next.RoundTrip(req)
+	invalidate()
+	return resp, err
+}
+
+// InvalidateBank drops the cached responses for a bank in every tenant,
+// for use after changes made by other clients.
+func (c *ResponseCache) InvalidateBank(bankID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.idGens[bankID]++
+	for bank := range c.banks {
+		if bank[strings.IndexByte(bank, '/')+1:] == bankID {
+			c.invalidateLocked(bank, nil)
+		}
+	}
+}
+
+// Purge drops every cached response.
+func (c *ResponseCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	c.entries = make(map[string]*list.Element)
+	c.banks = make(map[string]map[string]struct{})
+	for bank := range c.gens {
+		c.gens[bank]++
+	}
+}
+
+// Stats returns hit and miss counts and the number of cached responses.
+func (c *ResponseCache) Stats() ResponseCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ResponseCacheStats{Hits: c.hits, Misses: c.misses, Entries: c.lru.Len()}
+}
+
+func (c *ResponseCache) get(key string, req *http.Request) *http.Response {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if ok && !c.now().Before(el.Value.(*cacheEntry).expires) {
+		c.remove(el)
+		ok = false
+	}
+	if !ok || strings.Contains(req.Header.Get("Cache-Control"), "no-cache") {
+		c.misses++
+		return nil
+	}
+	c.hits++
+	c.lru.MoveToFront(el)
+	e := el.Value.(*cacheEntry)
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// cacheGen is the invalidation state of a bank when a read was sent.
+type cacheGen struct {
+	bank, id uint64
+}
+
+func (c *ResponseCache) put(e *cacheEntry, gen cacheGen) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gens[e.bank] != gen.bank || c.idGens[e.bankID] != gen.id {
+		return // the bank changed while the request was in flight
+	}
+	if el, ok := c.entries[e.key]; ok {
+		c.remove(el)
+	}
+	c.entries[e.key] = c.lru.PushFront(e)
+	if c.banks[e.bank] == nil {
+		c.banks[e.bank] = make(map[string]struct{})
+	}
+	c.banks[e.bank][e.key] = struct{}{}
+	for c.lru.Len() > c.cfg.MaxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *ResponseCache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*cacheEntry)
+	delete(c.entries, e.key)
+	if keys := c.banks[e.bank]; keys != nil {
+		delete(keys, e.key)
+		if len(keys) == 0 {
+			delete(c.banks, e.bank)
+		}
+	}
+}
+
+// invalidate drops the entries of bank, and of bank-less reads such as the
+// bank list. retained, if not nil, holds the tag sets of retained items:
+// recalls whose strict tag filter matches none of them are kept.
+func (c *ResponseCache) invalidate(bank string, retained [][]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidateLocked(bank, retained)
+}
+
+func (c *ResponseCache) invalidateLocked(bank string, retained [][]string) {
+	tenant := bank[:strings.IndexByte(bank, '/')+1]
+	for _, b := range []string{bank, tenant} {
+		c.gens[b]++
+		for key := range c.banks[b] {
+			el := c.entries[key]
+			if e := el.Value.(*cacheEntry); retained == nil || e.filter == nil || e.filter.matchesAny(retained) {
+				c.remove(el)
+			}
+		}
+	}
+}
+
+// expiry returns when resp stops being fresh, and false if it must not be
+// cached.
+func (c *ResponseCache) expiry(resp *http.Response) (time.Time, bool) {
+	cc := resp.Header.Get("Cache-Control")
+	if cc == "" && resp.Header.Get("Expires") == "" {
+		return c.now().Add(c.cfg.TTL), true
+	}
+	if strings.Contains(cc, "no-store") || strings.Contains(cc, "no-cache") {
+		return time.Time{}, false
+	}
+	expires := CacheExpires(resp)
+	return expires, expires.After(c.now())
+}
+
+func (f *strictTagFilter) matchesAny(tagSets [][]string) bool {
+	for _, tags := range tagSets {
+		have := make(map[string]bool, len(tags))
+		for _, t := range tags {
+			have[t] = true
+		}
+		matched := f.all
+		for _, t := range f.tags {
+			if f.all && !have[t] {
+				matched = false
+				break
+			}
+			if !f.all && have[t] {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheKey returns the normalized key for a cacheable request and, for a
+// recall, its strict tag filter. ok is false if the request cannot be
+// cached.
+func cacheKey(req *http.Request) (key string, filter *strictTagFilter, ok bool) {
+	h := sha256.New()
+	io.WriteString(h, req.Method+" "+req.URL.Scheme+"://"+req.URL.Host+req.URL.Path+"?"+req.URL.Query().Encode()+"\n")
+	io.WriteString(h, requestCredential(req)+"\n")
+	if req.Method != http.MethodGet {
+		body, err := requestBody(req)
+		if err != nil {
+			return "", nil, false
+		}
+		var recall map[string]interface{}
+		d := json.NewDecoder(bytes.NewReader(body))
+		d.UseNumber()
+		if err := d.Decode(&recall); err != nil {
+			return "", nil, false
+		}
+		sortStrings(recall["types"])
+		sortStrings(recall["tags"])
+		normalized, err := json.Marshal(recall)
+		if err != nil {
+			return "", nil, false
+		}
+		h.Write(normalized)
+		if match, _ := recall["tags_match"].(string); strings.HasSuffix(match, "_strict") {
+			filter = &strictTagFilter{all: match == "all_strict", tags: stringList(recall["tags"])}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), filter, true
+}
+
+// requestCredential returns the credentials of a request: the
+// Authorization header and the lower-case "authorization" key under which
+// the generated builders store per-call overrides, which Header.Get does
+// not see.
+func requestCredential(req *http.Request) string {
+	return strings.Join(req.Header.Values("Authorization"), ",") + "\x00" + strings.Join(req.Header["authorization"], ",")
+}
+
+// credentialKeyedKey marks a request context whose request was keyed on its
+// credentials by ResponseCache or SingleflightMiddleware.
+type credentialKeyedKey struct{}
+
+func markCredentialKeyed(req *http.Request) *http.Request {
+	if req.Context().Value(credentialKeyedKey{}) != nil {
+		return req
+	}
+	return req.WithContext(context.WithValue(req.Context(), credentialKeyedKey{}, true))
+}
+
+// errTokenSourceInsideCache is returned when a token source would add a
+// credential to a request that was already keyed without it.
+var errTokenSourceInsideCache = errors.New("hindsight: SetTokenSource was called before ResponseCache or SingleflightMiddleware was installed; install them first so requests are keyed on their credentials")
+
+// retainedTags returns the tags each retained item will carry, its own plus
+// the request's document_tags.
+func retainedTags(req *http.Request) ([][]string, bool) {
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, false
+	}
+	var retain struct {
+		Items []struct {
+			Tags []string `json:"tags"`
+		} `json:"items"`
+		DocumentTags []string `json:"document_tags"`
+	}
+	if err := json.Unmarshal(body, &retain); err != nil {
+		return nil, false
+	}
+	tags := make([][]string, len(retain.Items))
+	for i, item := range retain.Items {
+		tags[i] = append(item.Tags, retain.DocumentTags...)
+	}
+	return tags, true
+}
+
+// requestBody reads a copy of the request body without consuming it.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody == nil {
+		return nil, errNoGetBody
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+var errNoGetBody = errors.New("hindsight: request body cannot be replayed")
+
+func sortStrings(v interface{}) {
+	list, _ := v.([]interface{})
+	sort.SliceStable(list, func(i, j int) bool {
+		a, _ := list[i].(string)
+		b, _ := list[j].(string)
+		return a < b
+	})
+}
+
+func stringList(v interface{}) []string {
+	list, _ := v.([]interface{})
+	out := make([]string, 0, len(list))
+	for _, x := range list {
+		if s, ok := x.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
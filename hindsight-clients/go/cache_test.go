@@ -0,0 +1,236 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	cacheControl := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		mu.Lock()
+		calls[r.Method+" "+r.URL.Path]++
+		cc := cacheControl
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if cc != "" {
+			w.Header().Set("Cache-Control", cc)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/default/banks/agent/memories/recall":
+			io.WriteString(w, `{"results":[{"id":"m1","text":"Alice prefers tea"}]}`)
+		case "POST /v1/default/banks/agent/memories":
+			io.WriteString(w, `{"success":true,"bank_id":"agent","items_count":1,"async":false}`)
+		case "GET /v1/default/banks/agent/documents":
+			io.WriteString(w, `{"items":[],"total":0,"limit":100,"offset":0}`)
+		case "DELETE /v1/default/banks/agent/documents/d1":
+			io.WriteString(w, `{"success":true,"message":"deleted","document_id":"d1","memory_units_deleted":1}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	cache := NewResponseCache(ResponseCacheConfig{TTL: time.Minute})
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	client, err := New(WithBaseURL(srv.URL), WithResponseCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bank := client.Bank("agent")
+	ctx := context.Background()
+	recalls := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls["POST /v1/default/banks/agent/memories/recall"]
+	}
+	recall := func(opts ...RecallOption) {
+		t.Helper()
+		if resp, err := bank.Recall(ctx, "tea", opts...); err != nil || resp.Results[0].Text != "Alice prefers tea" {
+			t.Fatalf("Recall = %+v, %v", resp, err)
+		}
+	}
+
+	recall(RecallTypes("world", "experience"))
+	recall(RecallTypes("experience", "world"))
+	if n := recalls(); n != 1 {
+		t.Fatalf("normalized repeat recall reached the server: %d calls", n)
+	}
+	if s := cache.Stats(); s.Hits != 1 || s.Misses != 1 || s.Entries != 1 {
+		t.Fatalf("stats = %+v", s)
+	}
+
+	// Retain to the bank invalidates its recalls.
+	bank.Retain(ctx, *NewMemoryItem("Bob prefers coffee"))
+	recall(RecallTypes("world", "experience"))
+	if n := recalls(); n != 2 {
+		t.Fatalf("recall after retain: %d calls, want 2", n)
+	}
+
+	// A strict tag filter survives retains whose tags cannot match it.
+	recall(RecallTags("any_strict", "user:alice"))
+	other := NewMemoryItem("Bob prefers coffee")
+	other.Tags = []string{"user:bob"}
+	bank.Retain(ctx, *other)
+	recall(RecallTags("any_strict", "user:alice"))
+	if n := recalls(); n != 3 {
+		t.Fatalf("strict recall after unrelated retain: %d calls, want 3", n)
+	}
+	alice := NewMemoryItem("Alice switched to coffee")
+	alice.Tags = []string{"user:alice"}
+	bank.Retain(ctx, *alice)
+	recall(RecallTags("any_strict", "user:alice"))
+	if n := recalls(); n != 4 {
+		t.Fatalf("strict recall after matching retain: %d calls, want 4", n)
+	}
+
+	// GET endpoints are cached and dropped by deletes in the bank.
+	for i := 0; i < 2; i++ {
+		if _, err := bank.Documents().List(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bank.Documents().Delete(ctx, "d1")
+	bank.Documents().List(ctx)
+	if n := calls["GET /v1/default/banks/agent/documents"]; n != 2 {
+		t.Fatalf("document lists: %d calls, want 2", n)
+	}
+
+	// Entries expire after the TTL.
+	now = now.Add(2 * time.Minute)
+	recall(RecallTags("any_strict", "user:alice"))
+	if n := recalls(); n != 5 {
+		t.Fatalf("recall after TTL: %d calls, want 5", n)
+	}
+
+	// Server caching headers take precedence over the TTL.
+	cache.Purge()
+	mu.Lock()
+	cacheControl = "no-store"
+	mu.Unlock()
+	recall()
+	recall()
+	if n := recalls(); n != 7 {
+		t.Fatalf("no-store responses were cached: %d calls, want 7", n)
+	}
+
+	mu.Lock()
+	cacheControl = "max-age=600"
+	mu.Unlock()
+	recall()
+	now = now.Add(5 * time.Minute) // past the TTL, within max-age
+	recall()
+	if n := recalls(); n != 8 {
+		t.Fatalf("max-age was not honoured: %d calls, want 8", n)
+	}
+}
+
+func TestResponseCacheKeys(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	server := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			calls[name]++
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"bank_id":"agent","name":"`+name+` `+r.Header.Get("Authorization")+`","disposition":{"skepticism":3,"literalism":3,"empathy":3},"mission":""}`)
+		}))
+	}
+	a, b := server("a"), server("b")
+	defer a.Close()
+	defer b.Close()
+
+	cache := NewResponseCache(ResponseCacheConfig{TTL: time.Minute})
+	client, err := New(WithBaseURL(a.URL), WithResponseCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := func(c *APIClient, token string) string {
+		t.Helper()
+		r := c.BanksAPI.GetBankProfile(context.Background(), "agent")
+		if token != "" {
+			r = r.Authorization(token)
+		}
+		profile, _, err := r.Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return profile.Name
+	}
+
+	// Per-call credentials get entries of their own.
+	for _, token := range []string{"Bearer one", "Bearer two", "Bearer one"} {
+		if got := get(client, token); got != "a "+token {
+			t.Fatalf("with %q got %q", token, got)
+		}
+	}
+	// So do clients sharing the cache but talking to another server.
+	if got := get(client.WithServer(b.URL), "Bearer one"); got != "b Bearer one" {
+		t.Fatalf("other server got %q", got)
+	}
+	mu.Lock()
+	if calls["a"] != 2 || calls["b"] != 1 {
+		t.Fatalf("calls = %v", calls)
+	}
+	mu.Unlock()
+
+	// A token source inside the cache would key requests without their
+	// credential, so it fails instead.
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: a.URL}}
+	cfg.SetTokenSource(StaticTokenSource("key"))
+	cfg.Use(cache.Middleware())
+	_, _, err = NewAPIClient(cfg).BanksAPI.GetBankProfile(context.Background(), "agent").Execute()
+	if !errors.Is(err, errTokenSourceInsideCache) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestResponseCacheInvalidateBankTenants(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"bank_id":"agent","name":"Agent","disposition":{"skepticism":3,"literalism":3,"empathy":3},"mission":""}`)
+	}))
+	defer srv.Close()
+
+	cache := NewResponseCache(ResponseCacheConfig{TTL: time.Minute})
+	client, err := New(WithBaseURL(srv.URL), WithResponseCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	acme := client.Tenant("acme")
+	ctx := context.Background()
+	get := func() {
+		t.Helper()
+		for _, c := range []*APIClient{client, acme.APIClient} {
+			if _, _, err := c.BanksAPI.GetBankProfile(ctx, "agent").Execute(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	get()
+	get()
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Fatalf("calls = %d, want one per tenant", n)
+	}
+	cache.InvalidateBank("agent")
+	get()
+	if n := atomic.LoadInt32(&calls); n != 4 {
+		t.Fatalf("calls after InvalidateBank = %d, want 4", n)
+	}
+}
@@ -0,0 +1,393 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when a request is rejected because the circuit
+// breaker for its endpoint is open. Use errors.Is to detect it and fall back
+// to a no-memory code path.
+var ErrCircuitOpen = errors.New("hindsight: circuit breaker is open")
+
+// CircuitState is the state of a single circuit.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests through while tracking failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all requests with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitKey identifies a circuit. Endpoint is always set to the scheme and
+// host of the server; BankID and Class are only set when the breaker is
+// configured to track them separately.
+type CircuitKey struct {
+	Endpoint string
+	BankID   string
+	Class    OperationClass
+}
+
+func (k CircuitKey) String() string {
+	s := k.Endpoint
+	if k.BankID != "" {
+		s += " bank=" + k.BankID
+	}
+	if k.Class != "" {
+		s += " class=" + string(k.Class)
+	}
+	return s
+}
+
+// CircuitOpenError is the typed error returned for rejected requests.
+// It matches ErrCircuitOpen with errors.Is.
+type CircuitOpenError struct {
+	Key CircuitKey
+	// RetryAfter is the time left until the circuit moves to half-open.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %s (retry in %s)", ErrCircuitOpen, e.Key, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreakerConfig configures a CircuitBreaker. Zero values select the
+// documented defaults.
+type CircuitBreakerConfig struct {
+	// FailureRatio is the ratio of failed requests within Window that opens
+	// the circuit. Defaults to 0.5.
+	FailureRatio float64
+	// MinRequests is the number of requests that must be observed within
+	// Window before FailureRatio is evaluated. Defaults to 10.
+	MinRequests int
+	// Window is the period over which failures are counted. Defaults to 30s.
+	Window time.Duration
+	// OpenTimeout is how long a circuit stays open before allowing probes.
+	// Defaults to 30s.
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests is the number of concurrent probes allowed while
+	// half-open; that many consecutive successes close the circuit.
+	// Defaults to 1.
+	HalfOpenMaxRequests int
+	// PerBank tracks a separate circuit for every bank ID.
+	PerBank bool
+	// PerOperationClass tracks a separate circuit for retain, recall,
+	// reflect and other operations.
+	PerOperationClass bool
+	// IsFailure classifies a completed round trip. The default treats
+	// transport errors and 5xx responses as failures. Round trips cancelled
+	// by the caller are neither failures nor successes and are not passed
+	// to IsFailure.
+	IsFailure func(resp *http.Response, err error) bool
+	// OnStateChange is called after a circuit changes state. It is called
+	// synchronously, outside the breaker's lock, and must not block.
+	OnStateChange func(key CircuitKey, from, to CircuitState)
+}
+
+// CircuitBreaker fails requests fast while the server or its LLM provider is
+// degraded. It tracks failure ratios per server endpoint, and optionally per
+// bank and operation class. Install it with Configuration.Use.
+//
+// Circuits without requests in flight are dropped once they hold nothing a
+// new circuit would not: closed and half-open circuits after Window, open
+// ones after OpenTimeout plus Window. A PerBank breaker therefore only keeps
+// the banks in recent use.
+type CircuitBreaker struct {
+	cfg CircuitBreakerConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	circuits map[CircuitKey]*circuit
+	// sweepAt is the number of circuits at which idle ones are dropped.
+	sweepAt int
+}
+
+// minCircuitSweep is the smallest number of circuits that triggers a sweep.
+const minCircuitSweep = 64
+
+type circuit struct {
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+	successes   int
+	generation  uint64
+	inFlight    int
+}
+
+type circuitTransition struct {
+	key      CircuitKey
+	from, to CircuitState
+}
+
+// NewCircuitBreaker returns a CircuitBreaker using cfg.
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.FailureRatio <= 0 {
+		cfg.FailureRatio = 0.5
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = 10
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 30 * time.Second
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenMaxRequests <= 0 {
+		cfg.HalfOpenMaxRequests = 1
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = defaultIsFailure
+	}
+	return &CircuitBreaker{
+		cfg:      cfg,
+		now:      time.Now,
+		circuits: make(map[CircuitKey]*circuit),
+		sweepAt:  minCircuitSweep,
+	}
+}
+
+func defaultIsFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500
+}
+
+// Middleware returns the breaker as a Middleware for Configuration.Use.
+func (b *CircuitBreaker) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			key := b.keyFor(req)
+			c, generation, err := b.allow(key)
+			if err != nil {
+				closeRequestBody(req)
+				return nil, err
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil && errors.Is(err, context.Canceled) {
+				// A cancelled request says nothing about the server's
+				// health, so it must not close a half-open circuit.
+				b.release(key, c, generation)
+				return resp, err
+			}
+			b.record(key, c, generation, b.cfg.IsFailure(resp, err))
+			return resp, err
+		})
+	}
+}
+
+// State returns the current state of the circuit for key.
+func (b *CircuitBreaker) State(key CircuitKey) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[key]
+	if !ok {
+		return CircuitClosed
+	}
+	if c.state == CircuitOpen && b.now().Sub(c.openedAt) >= b.cfg.OpenTimeout {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// Reset closes every circuit and discards all recorded counts.
+func (b *CircuitBreaker) Reset() {
+	b.mu.Lock()
+	var transitions []circuitTransition
+	for key, c := range b.circuits {
+		if c.state != CircuitClosed {
+			transitions = append(transitions, circuitTransition{key, c.state, CircuitClosed})
+		}
+	}
+	b.circuits = make(map[CircuitKey]*circuit)
+	b.mu.Unlock()
+	b.notify(transitions)
+}
+
+func (b *CircuitBreaker) keyFor(req *http.Request) CircuitKey {
+	key := CircuitKey{Endpoint: req.URL.Scheme + "://" + req.URL.Host}
+	if b.cfg.PerBank || b.cfg.PerOperationClass {
+		op := OperationForRequest(req)
+		if b.cfg.PerBank {
+			key.BankID = op.BankID
+		}
+		if b.cfg.PerOperationClass {
+			key.Class = op.Class
+		}
+	}
+	return key
+}
+
+// allow decides whether a request may proceed and returns the circuit and
+// generation it was admitted under, so that results arriving after a state
+// change or a Reset are not attributed to the new state.
+func (b *CircuitBreaker) allow(key CircuitKey) (*circuit, uint64, error) {
+	b.mu.Lock()
+	var transitions []circuitTransition
+	defer func() {
+		b.mu.Unlock()
+		b.notify(transitions)
+	}()
+
+	now := b.now()
+	c, ok := b.circuits[key]
+	if !ok {
+		if len(b.circuits) >= b.sweepAt {
+			b.sweepLocked(now)
+		}
+		c = &circuit{windowStart: now}
+		b.circuits[key] = c
+	}
+
+	switch c.state {
+	case CircuitClosed:
+		if now.Sub(c.windowStart) >= b.cfg.Window {
+			c.windowStart = now
+			c.requests = 0
+			c.failures = 0
+		}
+		c.inFlight++
+		return c, c.generation, nil
+	case CircuitOpen:
+		if elapsed := now.Sub(c.openedAt); elapsed < b.cfg.OpenTimeout {
+			return nil, 0, &CircuitOpenError{Key: key, RetryAfter: b.cfg.OpenTimeout - elapsed}
+		}
+		transitions = append(transitions, b.setState(key, c, CircuitHalfOpen, now))
+	}
+
+	// Half-open: admit a bounded number of probes.
+	if c.probes >= b.cfg.HalfOpenMaxRequests {
+		return nil, 0, &CircuitOpenError{Key: key}
+	}
+	c.probes++
+	c.inFlight++
+	return c, c.generation, nil
+}
+
+// sweepLocked drops idle circuits and sets the next sweep threshold to
+// twice the number kept, so sweeps cost amortized constant time per new
+// circuit. Callers must hold b.mu.
+func (b *CircuitBreaker) sweepLocked(now time.Time) {
+	for key, c := range b.circuits {
+		if b.idle(c, now) {
+			delete(b.circuits, key)
+		}
+	}
+	b.sweepAt = 2 * len(b.circuits)
+	if b.sweepAt < minCircuitSweep {
+		b.sweepAt = minCircuitSweep
+	}
+}
+
+// idle reports whether dropping c loses nothing: no request is in flight
+// and a new closed circuit would start from the same counts.
+func (b *CircuitBreaker) idle(c *circuit, now time.Time) bool {
+	if c.inFlight > 0 {
+		return false
+	}
+	if c.state == CircuitOpen {
+		return now.Sub(c.openedAt) >= b.cfg.OpenTimeout+b.cfg.Window
+	}
+	return now.Sub(c.windowStart) >= b.cfg.Window
+}
+
+func (b *CircuitBreaker) record(key CircuitKey, c *circuit, generation uint64, failed bool) {
+	b.mu.Lock()
+	var transitions []circuitTransition
+	defer func() {
+		b.mu.Unlock()
+		b.notify(transitions)
+	}()
+
+	c.inFlight--
+	if b.circuits[key] != c || c.generation != generation {
+		return
+	}
+	now := b.now()
+
+	switch c.state {
+	case CircuitClosed:
+		c.requests++
+		if failed {
+			c.failures++
+		}
+		if c.requests >= b.cfg.MinRequests &&
+			float64(c.failures)/float64(c.requests) >= b.cfg.FailureRatio {
+			transitions = append(transitions, b.setState(key, c, CircuitOpen, now))
+		}
+	case CircuitHalfOpen:
+		c.probes--
+		if failed {
+			transitions = append(transitions, b.setState(key, c, CircuitOpen, now))
+			return
+		}
+		c.successes++
+		if c.successes >= b.cfg.HalfOpenMaxRequests {
+			transitions = append(transitions, b.setState(key, c, CircuitClosed, now))
+		}
+	}
+}
+
+// release frees the probe slot of a request whose outcome is neutral,
+// leaving the circuit's state and counts unchanged.
+func (b *CircuitBreaker) release(key CircuitKey, c *circuit, generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c.inFlight--
+	if b.circuits[key] == c && c.generation == generation && c.state == CircuitHalfOpen {
+		c.probes--
+	}
+}
+
+// setState moves c to state and resets its counters. Callers must hold b.mu.
+func (b *CircuitBreaker) setState(key CircuitKey, c *circuit, state CircuitState, now time.Time) circuitTransition {
+	t := circuitTransition{key: key, from: c.state, to: state}
+	c.state = state
+	c.generation++
+	c.windowStart = now
+	c.requests = 0
+	c.failures = 0
+	c.probes = 0
+	c.successes = 0
+	if state == CircuitOpen {
+		c.openedAt = now
+	}
+	return t
+}
+
+func (b *CircuitBreaker) notify(transitions []circuitTransition) {
+	if b.cfg.OnStateChange == nil {
+		return
+	}
+	for _, t := range transitions {
+		b.cfg.OnStateChange(t.key, t.from, t.to)
+	}
+}
@@ -0,0 +1,207 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	var failing int32 = 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"bank_id":"b","name":"b","disposition":{"skepticism":3,"literalism":3,"empathy":3},"mission":""}`))
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	var transitions []string
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{
+		MinRequests: 3,
+		OpenTimeout: time.Minute,
+		OnStateChange: func(key CircuitKey, from, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	breaker.now = func() time.Time { return now }
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	cfg.Use(breaker.Middleware())
+	client := NewAPIClient(cfg)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.BanksAPI.GetBankProfile(ctx, "b").Execute(); err == nil {
+			t.Fatalf("request %d: expected server error", i)
+		}
+	}
+	_, _, err := client.BanksAPI.GetBankProfile(ctx, "b").Execute()
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || openErr.Key.Endpoint != srv.URL {
+		t.Fatalf("expected CircuitOpenError for %s, got %#v", srv.URL, openErr)
+	}
+
+	atomic.StoreInt32(&failing, 0)
+	now = now.Add(time.Minute)
+	if _, _, err := client.BanksAPI.GetBankProfile(ctx, "b").Execute(); err != nil {
+		t.Fatalf("probe request failed: %v", err)
+	}
+	if got := breaker.State(CircuitKey{Endpoint: srv.URL}); got != CircuitClosed {
+		t.Fatalf("expected closed circuit, got %s", got)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Fatalf("transitions = %v, want %v", transitions, want)
+		}
+	}
+}
+
+func TestCircuitBreakerPerBankAndClass(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 1, PerBank: true, PerOperationClass: true})
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	cfg.Use(breaker.Middleware())
+	client := NewAPIClient(cfg)
+	ctx := context.Background()
+
+	client.MemoryAPI.RecallMemories(ctx, "a").RecallRequest(RecallRequest{Query: "q"}).Execute()
+
+	recallA := CircuitKey{Endpoint: srv.URL, BankID: "a", Class: OperationClassRecall}
+	if got := breaker.State(recallA); got != CircuitOpen {
+		t.Fatalf("recall circuit for bank a: got %s, want open", got)
+	}
+	if got := breaker.State(CircuitKey{Endpoint: srv.URL, BankID: "b", Class: OperationClassRecall}); got != CircuitClosed {
+		t.Fatalf("recall circuit for bank b: got %s, want closed", got)
+	}
+	if got := breaker.State(CircuitKey{Endpoint: srv.URL, BankID: "a", Class: OperationClassRetain}); got != CircuitClosed {
+		t.Fatalf("retain circuit for bank a: got %s, want closed", got)
+	}
+}
+
+func TestOperationForRequest(t *testing.T) {
+	tests := []struct {
+		method, url string
+		want        Operation
+	}{
+		{http.MethodPost, "http://h/v1/default/banks/my%20bank/memories/recall", Operation{Name: "MemoryAPIService.RecallMemories", Tenant: "default", BankID: "my bank", Class: OperationClassRecall}},
+		{http.MethodGet, "http://h/base/v1/default/banks/b/memories/list", Operation{Name: "MemoryAPIService.ListMemories", Tenant: "default", BankID: "b", Class: OperationClassOther}},
+		{http.MethodGet, "http://h/v1/default/banks/b/memories/m1", Operation{Name: "MemoryAPIService.GetMemory", Tenant: "default", BankID: "b", Class: OperationClassOther}},
+		{http.MethodGet, "http://h/v1/default/banks", Operation{Name: "BanksAPIService.ListBanks", Tenant: "default", Class: OperationClassOther}},
+		{http.MethodGet, "http://h/v1/acme/banks/b/stats", Operation{Name: "BanksAPIService.GetAgentStats", Tenant: "acme", BankID: "b", Class: OperationClassOther}},
+		{http.MethodGet, "http://h/version", Operation{Name: "MonitoringAPIService.GetVersion", Class: OperationClassOther}},
+		{http.MethodGet, "http://h/unknown", Operation{Class: OperationClassOther}},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, tt.url, nil)
+		if got := OperationForRequest(req); got != tt.want {
+			t.Errorf("%s %s: got %+v, want %+v", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestCircuitBreakerCancelledProbe(t *testing.T) {
+	var failing int32 = 1
+	arrived := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		arrived <- struct{}{}
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 1, OpenTimeout: time.Minute})
+	breaker.now = func() time.Time { return now }
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	cfg.Use(breaker.Middleware())
+	client := NewAPIClient(cfg)
+	key := CircuitKey{Endpoint: srv.URL}
+
+	client.BanksAPI.GetBankProfile(context.Background(), "b").Execute()
+	if got := breaker.State(key); got != CircuitOpen {
+		t.Fatalf("state = %s, want open", got)
+	}
+
+	// The caller cancels the probe before the server answers.
+	atomic.StoreInt32(&failing, 0)
+	now = now.Add(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-arrived
+		cancel()
+	}()
+	if _, _, err := client.BanksAPI.GetBankProfile(ctx, "b").Execute(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("probe err = %v", err)
+	}
+	if got := breaker.State(key); got != CircuitHalfOpen {
+		t.Fatalf("state = %s, want half-open", got)
+	}
+
+	// The probe slot was released for the next probe.
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		<-arrived
+		cancel()
+	}()
+	if _, _, err := client.BanksAPI.GetBankProfile(ctx, "b").Execute(); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second probe rejected: %v", err)
+	}
+}
+
+func TestCircuitBreakerDropsIdleCircuits(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 1, Window: time.Minute, OpenTimeout: time.Hour, PerBank: true})
+	breaker.now = func() time.Time { return now }
+	rt := breaker.Middleware()(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		status := http.StatusOK
+		if OperationForRequest(req).BankID == "down" {
+			status = http.StatusBadGateway
+		}
+		return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+	}))
+	get := func(bank string) {
+		req, _ := http.NewRequest(http.MethodGet, "http://h/v1/default/banks/"+bank+"/profile", nil)
+		if resp, err := rt.RoundTrip(req); err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	get("down")
+	for i := 0; i < 2000; i++ {
+		get(fmt.Sprint("bank-", i))
+		now = now.Add(time.Second)
+		if n := len(breaker.circuits); n > 200 {
+			t.Fatalf("%d circuits after %d banks", n, i+1)
+		}
+	}
+	if got := breaker.State(CircuitKey{Endpoint: "http://h", BankID: "down"}); got != CircuitOpen {
+		t.Fatalf("open circuit was dropped: %s", got)
+	}
+}
@@ -266,6 +266,7 @@ func parameterToJson(obj interface{}) (string, error) {
 
 // callAPI do the request.
 func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
+	request = setRequestID(request)
 	if c.cfg.Debug {
 		dump, err := httputil.DumpRequestOut(request, true)
 		if err != nil {
@@ -276,8 +277,9 @@ func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
 
 	resp, err := c.cfg.HTTPClient.Do(request)
 	if err != nil {
-		return resp, err
+		return resp, withRequestID(request, unixSocketError(request, err))
 	}
+	decodeContentEncoding(resp)
 
 	if c.cfg.Debug {
 		dump, err := httputil.DumpResponse(resp, true)
@@ -290,7 +292,8 @@ func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
 }
 
 // Allow modification of underlying config for alternate implementations and testing
-// Caution: modifying the configuration while live can cause data races and potentially unwanted behavior
+// Caution: the configuration is frozen once the client is in use; modifying it races with
+// requests in flight. Derive a changed client with Clone or the With methods instead.
 func (c *APIClient) GetConfig() *Configuration {
 	return c.cfg
 }
@@ -310,6 +313,11 @@ func (c *APIClient) prepareRequest(
 	queryParams url.Values,
 	formParams url.Values,
 	formFiles []formFile) (localVarRequest *http.Request, err error) {
+	if c.cfg.ValidateRequests {
+		if err = validateBody(postBody); err != nil {
+			return nil, err
+		}
+	}
 
 	var body *bytes.Buffer
 
@@ -638,11 +646,12 @@ type GenericOpenAPIError struct {
 	body  []byte
 	error string
 	model interface{}
+	requestID string
 }
 
 // Error returns non-empty string if there was an error.
 func (e GenericOpenAPIError) Error() string {
-	return e.error
+	return e.error + requestIDSuffix(e.requestID)
 }
 
 // Body returns the raw bytes of the response
@@ -655,6 +664,11 @@ func (e GenericOpenAPIError) Model() interface{} {
 	return e.model
 }
 
+// RequestID returns the ID of the request that failed
+func (e GenericOpenAPIError) RequestID() string {
+	return e.requestID
+}
+
 // format error message using title and detail when model implements rfc7807
 func formatErrorMessage(status string, v interface{}) string {
 	str := ""
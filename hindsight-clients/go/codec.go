@@ -0,0 +1,594 @@
+package hindsight
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// The generated models originally encoded through ToMap, building a
+// map[string]interface{} per object, and decoded twice: once into a generic
+// map to check required properties and once into the model. marshalModel
+// and checkRequiredProperties replace those paths with a cached field plan
+// and a key-only scan, producing byte-identical JSON. ToMap is kept for
+// callers that want a map; the codec tests compare both encodings for
+// randomly filled values of every model.
+
+// modelField describes how one struct field of a model is encoded.
+type modelField struct {
+	name  string // JSON key
+	key   string // quoted JSON key followed by ':'
+	index int
+	// omit reports whether an optional field is left out; nil for required
+	// fields, which are always written.
+	omit   func(reflect.Value) bool
+	encode encodeFunc
+}
+
+// encodeFunc appends the JSON encoding of v to b.
+type encodeFunc func(b []byte, v reflect.Value) ([]byte, error)
+
+var (
+	modelFieldCache sync.Map // reflect.Type -> []modelField
+	encoderCache    sync.Map // reflect.Type -> encodeFunc
+
+	isSetterType      = reflect.TypeOf((*interface{ IsSet() bool })(nil)).Elem()
+	mappedType        = reflect.TypeOf((*MappedNullable)(nil)).Elem()
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	nullableValueType = reflect.TypeOf((*nullableValue)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+	stringMapType     = reflect.TypeOf(map[string]string(nil))
+	anyMapType        = reflect.TypeOf(map[string]interface{}(nil))
+)
+
+// marshalModel encodes a generated model struct directly from its fields.
+// Nested models, slices, maps and plain values are written into the same
+// buffer rather than through their own MarshalJSON, which would have
+// encoding/json validate and compact every nested object again.
+func marshalModel(model interface{}) ([]byte, error) {
+	return appendModel(make([]byte, 0, 256), reflect.ValueOf(model))
+}
+
+func appendModel(b []byte, v reflect.Value) ([]byte, error) {
+	b = append(b, '{')
+	first := true
+	for _, f := range modelFields(v.Type()) {
+		fv := v.Field(f.index)
+		if f.omit != nil && f.omit(fv) {
+			continue
+		}
+		if !first {
+			b = append(b, ',')
+		}
+		first = false
+		b = append(b, f.key...)
+		var err error
+		if b, err = f.encode(b, fv); err != nil {
+			return []byte{}, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+func modelFields(t reflect.Type) []modelField {
+	if cached, ok := modelFieldCache.Load(t); ok {
+		return cached.([]modelField)
+	}
+	var fields []modelField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if sf.PkgPath != "" || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		f := modelField{
+			name:   name,
+			key:    string(appendJSONString(nil, name)) + ":",
+			index:  i,
+			encode: encoderFor(sf.Type),
+		}
+		if strings.Contains(","+opts+",", ",omitempty,") {
+			f.omit = omitFunc(sf.Type)
+		}
+		fields = append(fields, f)
+	}
+	// Map keys are encoded sorted by their unquoted bytes; keep the same
+	// order.
+	sort.Slice(fields, func(a, b int) bool { return fields[a].name < fields[b].name })
+	modelFieldCache.Store(t, fields)
+	return fields
+}
+
+// omitFunc mirrors the conditions the generated ToMap methods use: unset
+// Nullable fields and nil pointers, slices, maps and interfaces are omitted.
+func omitFunc(t reflect.Type) func(reflect.Value) bool {
+	if isNullableType(t) {
+		sf, _ := t.FieldByName("isSet")
+		index := sf.Index
+		return func(v reflect.Value) bool { return !v.FieldByIndex(index).Bool() }
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return func(v reflect.Value) bool { return v.IsNil() }
+	}
+	return nil
+}
+
+func isNullableType(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || !t.Implements(isSetterType) {
+		return false
+	}
+	isSet, ok := t.FieldByName("isSet")
+	if !ok || isSet.Type.Kind() != reflect.Bool {
+		return false
+	}
+	value, ok := t.FieldByName("value")
+	return ok && value.Type.Kind() == reflect.Ptr
+}
+
+// isModelType reports whether t is a generated model encoded by
+// marshalModel.
+func isModelType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Implements(mappedType) && t.Implements(marshalerType)
+}
+
+func hasCustomEncoding(t reflect.Type) bool {
+	pt := reflect.PtrTo(t)
+	return t.Implements(marshalerType) || t.Implements(textMarshalerType) ||
+		pt.Implements(marshalerType) || pt.Implements(textMarshalerType)
+}
+
+// encoderFor returns the encoder for values of type t. Anything it does not
+// handle itself goes through encoding/json, so the output always matches.
+func encoderFor(t reflect.Type) encodeFunc {
+	if cached, ok := encoderCache.Load(t); ok {
+		return cached.(encodeFunc)
+	}
+	enc := newEncoder(t)
+	encoderCache.Store(t, enc)
+	return enc
+}
+
+func newEncoder(t reflect.Type) encodeFunc {
+	switch {
+	case isModelType(t):
+		return appendModel
+	case isNullableType(t):
+		return newNullableEncoder(t)
+	case t == timeType:
+		return appendTime
+	case hasCustomEncoding(t):
+		return appendFallback
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return func(b []byte, v reflect.Value) ([]byte, error) { return appendJSONString(b, v.String()), nil }
+	case reflect.Bool:
+		return func(b []byte, v reflect.Value) ([]byte, error) { return strconv.AppendBool(b, v.Bool()), nil }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(b []byte, v reflect.Value) ([]byte, error) { return strconv.AppendInt(b, v.Int(), 10), nil }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(b []byte, v reflect.Value) ([]byte, error) { return strconv.AppendUint(b, v.Uint(), 10), nil }
+	case reflect.Float32, reflect.Float64:
+		return appendFloat
+	case reflect.Interface:
+		return func(b []byte, v reflect.Value) ([]byte, error) {
+			if v.IsNil() {
+				return append(b, "null"...), nil
+			}
+			return appendAny(b, v.Interface())
+		}
+	case reflect.Ptr:
+		elem := encoderFor(t.Elem())
+		return func(b []byte, v reflect.Value) ([]byte, error) {
+			if v.IsNil() {
+				return append(b, "null"...), nil
+			}
+			return elem(b, v.Elem())
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			break // base64
+		}
+		elem := encoderFor(t.Elem())
+		return func(b []byte, v reflect.Value) ([]byte, error) {
+			if v.IsNil() {
+				return append(b, "null"...), nil
+			}
+			b = append(b, '[')
+			for i := 0; i < v.Len(); i++ {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				var err error
+				if b, err = elem(b, v.Index(i)); err != nil {
+					return b, err
+				}
+			}
+			return append(b, ']'), nil
+		}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String || hasCustomEncoding(t.Key()) {
+			break
+		}
+		switch t {
+		case stringMapType:
+			return appendStringMap
+		case anyMapType:
+			return appendAnyMap
+		}
+		elem := encoderFor(t.Elem())
+		return func(b []byte, v reflect.Value) ([]byte, error) {
+			if v.IsNil() {
+				return append(b, "null"...), nil
+			}
+			keys := v.MapKeys()
+			sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+			b = append(b, '{')
+			for i, k := range keys {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = appendJSONString(b, k.String())
+				b = append(b, ':')
+				var err error
+				if b, err = elem(b, v.MapIndex(k)); err != nil {
+					return b, err
+				}
+			}
+			return append(b, '}'), nil
+		}
+	}
+	return appendFallback
+}
+
+// newNullableEncoder encodes a Nullable[T] through its value pointer, the
+// same way Nullable.MarshalJSON does.
+func newNullableEncoder(t reflect.Type) encodeFunc {
+	if !t.Implements(nullableValueType) {
+		return appendFallback
+	}
+	sf, _ := t.FieldByName("value")
+	enc := encoderFor(sf.Type)
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		return enc(b, reflect.ValueOf(v.Interface().(nullableValue).jsonValue()))
+	}
+}
+
+// appendStringMap and appendAnyMap encode the common metadata and trace
+// maps without reflecting over every entry.
+func appendStringMap(b []byte, v reflect.Value) ([]byte, error) {
+	m := v.Interface().(map[string]string)
+	if m == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	for i, k := range sortedKeys(m) {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONString(b, k)
+		b = append(b, ':')
+		b = appendJSONString(b, m[k])
+	}
+	return append(b, '}'), nil
+}
+
+func appendAnyMap(b []byte, v reflect.Value) ([]byte, error) {
+	m := v.Interface().(map[string]interface{})
+	if m == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	for i, k := range sortedKeys(m) {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONString(b, k)
+		b = append(b, ':')
+		var err error
+		if b, err = appendAny(b, m[k]); err != nil {
+			return b, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+func appendAny(b []byte, x interface{}) ([]byte, error) {
+	switch x := x.(type) {
+	case nil:
+		return append(b, "null"...), nil
+	case string:
+		return appendJSONString(b, x), nil
+	case bool:
+		return strconv.AppendBool(b, x), nil
+	}
+	return encoderFor(reflect.TypeOf(x))(b, reflect.ValueOf(x))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// appendTime formats a time.Time like time.Time.MarshalJSON, which rejects
+// years outside [0,9999]; those go through it to produce the same error.
+func appendTime(b []byte, v reflect.Value) ([]byte, error) {
+	t := v.Interface().(time.Time)
+	if y := t.Year(); y < 0 || y >= 10000 {
+		return appendFallback(b, v)
+	}
+	b = append(b, '"')
+	b = t.AppendFormat(b, time.RFC3339Nano)
+	return append(b, '"'), nil
+}
+
+func appendFallback(b []byte, v reflect.Value) ([]byte, error) {
+	if v.CanAddr() {
+		// Like encoding/json, use pointer-receiver MarshalJSON methods
+		// on addressable values such as slice elements.
+		v = v.Addr()
+	}
+	out, err := json.Marshal(v.Interface())
+	if err != nil {
+		return b, err
+	}
+	return append(b, out...), nil
+}
+
+// appendFloat formats floats the way encoding/json does.
+func appendFloat(b []byte, v reflect.Value) ([]byte, error) {
+	bits := v.Type().Bits()
+	f := v.Float()
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return b, &json.UnsupportedValueError{Value: v, Str: strconv.FormatFloat(f, 'g', -1, bits)}
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b, nil
+}
+
+// asciiEscapes holds how encoding/json escapes each ASCII byte, or nil for
+// bytes written as is. It and the escapes below are taken from encoding/json
+// itself because they differ between Go releases.
+var asciiEscapes = func() (t [utf8.RuneSelf][]byte) {
+	for c := 0; c < utf8.RuneSelf; c++ {
+		if esc := jsonStringBody(string(rune(c))); len(esc) != 1 {
+			t[c] = esc
+		}
+	}
+	return t
+}()
+
+var (
+	invalidUTF8Escape = jsonStringBody("\xff")
+	lineSepEscape     = jsonStringBody("\u2028")
+	paraSepEscape     = jsonStringBody("\u2029")
+)
+
+func jsonStringBody(s string) []byte {
+	out, _ := json.Marshal(s)
+	return out[1 : len(out)-1]
+}
+
+// appendJSONString appends s as a JSON string, escaped like encoding/json
+// with HTML escaping enabled.
+func appendJSONString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if esc := asciiEscapes[c]; esc != nil {
+				b = append(b, s[start:i]...)
+				b = append(b, esc...)
+				start = i + 1
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		var esc []byte
+		switch {
+		case r == utf8.RuneError && size == 1:
+			esc = invalidUTF8Escape
+		case r == '\u2028':
+			esc = lineSepEscape
+		case r == '\u2029':
+			esc = paraSepEscape
+		}
+		if esc != nil {
+			b = append(b, s[start:i]...)
+			b = append(b, esc...)
+			start = i + size
+		}
+		i += size
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
+
+// checkRequiredProperties returns the error the generated models report when
+// one of required is not a key of the JSON object in data. Only the
+// top-level keys are scanned; values are skipped without being decoded.
+func checkRequiredProperties(data []byte, required []string) error {
+	if len(required) == 0 {
+		return nil
+	}
+	found, ok := scanObjectKeys(data, required)
+	if !ok {
+		// Not a well-formed object: let encoding/json report it the way
+		// the generated code always has.
+		var all map[string]interface{}
+		if err := json.Unmarshal(data, &all); err != nil {
+			return err
+		}
+		found = make([]bool, len(required))
+		for i, name := range required {
+			_, found[i] = all[name]
+		}
+	}
+	for i, name := range required {
+		if !found[i] {
+			return fmt.Errorf("no value given for required property %v", name)
+		}
+	}
+	return nil
+}
+
+// scanObjectKeys reports which of names appear as top-level keys of the JSON
+// object in data. ok is false if data is not an object this scanner can
+// walk; the values themselves are validated later by the real decode.
+func scanObjectKeys(data []byte, names []string) (found []bool, ok bool) {
+	found = make([]bool, len(names))
+	i := skipJSONSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return nil, false
+	}
+	i++
+	for {
+		i = skipJSONSpace(data, i)
+		if i < len(data) && data[i] == '}' {
+			return found, true
+		}
+		if i >= len(data) || data[i] != '"' {
+			return nil, false
+		}
+		end, escaped := scanJSONString(data, i)
+		if end < 0 {
+			return nil, false
+		}
+		key := data[i+1 : end-1]
+		if escaped {
+			var s string
+			if json.Unmarshal(data[i:end], &s) != nil {
+				return nil, false
+			}
+			key = []byte(s)
+		}
+		for j, name := range names {
+			if string(key) == name {
+				found[j] = true
+			}
+		}
+		i = skipJSONSpace(data, end)
+		if i >= len(data) || data[i] != ':' {
+			return nil, false
+		}
+		i = skipJSONValue(data, skipJSONSpace(data, i+1))
+		if i < 0 {
+			return nil, false
+		}
+		i = skipJSONSpace(data, i)
+		if i >= len(data) {
+			return nil, false
+		}
+		switch data[i] {
+		case ',':
+			i++
+		case '}':
+			return found, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// scanJSONString returns the index just past the string starting at the
+// quote at data[i], or -1 if it is unterminated.
+func scanJSONString(data []byte, i int) (end int, escaped bool) {
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			escaped = true
+			j++
+		case '"':
+			return j + 1, escaped
+		}
+	}
+	return -1, escaped
+}
+
+// skipJSONValue returns the index just past the value starting at data[i],
+// or -1 if it is truncated.
+func skipJSONValue(data []byte, i int) int {
+	if i >= len(data) {
+		return -1
+	}
+	switch data[i] {
+	case '"':
+		end, _ := scanJSONString(data, i)
+		return end
+	case '{', '[':
+		depth := 0
+		for i < len(data) {
+			switch data[i] {
+			case '"':
+				end, _ := scanJSONString(data, i)
+				if end < 0 {
+					return -1
+				}
+				i = end
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+			i++
+		}
+		return -1
+	}
+	for i < len(data) {
+		switch data[i] {
+		case ',', '}', ']', ' ', '\t', '\r', '\n':
+			return i
+		}
+		i++
+	}
+	return i
+}
@@ -0,0 +1,463 @@
+package hindsight
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// legacyMarshal is the encoding the generated models used before
+// marshalModel: every model, nested ones included, built its ToMap map and
+// encoded that.
+func legacyMarshal(m MappedNullable) ([]byte, error) {
+	return json.Marshal(legacyModel{m})
+}
+
+type legacyModel struct{ MappedNullable }
+
+func (m legacyModel) MarshalJSON() ([]byte, error) {
+	toSerialize, err := m.ToMap()
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range toSerialize {
+		toSerialize[k] = legacyValue(v)
+	}
+	return json.Marshal(toSerialize)
+}
+
+// legacyValue wraps models, and slices and maps of models, so that they
+// encode through legacyModel.
+func legacyValue(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return v // encodes as null
+	}
+	if m, ok := v.(MappedNullable); ok {
+		return legacyModel{m}
+	}
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() || !rv.Type().Elem().Implements(mappedType) {
+			return v
+		}
+		out := make([]interface{}, rv.Len())
+		for i := range out {
+			out[i] = legacyValue(rv.Index(i).Interface())
+		}
+		return out
+	case reflect.Map:
+		if rv.IsNil() || !rv.Type().Elem().Implements(mappedType) {
+			return v
+		}
+		out := make(map[string]interface{}, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			out[iter.Key().String()] = legacyValue(iter.Value().Interface())
+		}
+		return out
+	}
+	return v
+}
+
+func sampleRetainRequest(n int) RetainRequest {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	items := make([]MemoryItem, n)
+	for i := range items {
+		item := NewMemoryItem(fmt.Sprintf("Alice mentioned <item %d> & said she prefers tea over coffee", i))
+		item.Timestamp = NullableOf(ts.Add(time.Duration(i) * time.Minute))
+		item.Metadata = map[string]string{"source": "chat", "turn": fmt.Sprint(i)}
+		item.Entities = []EntityInput{{Text: "Alice", Type: NullableOf("person")}, {Text: "tea"}}
+		item.Tags = []string{"user:alice", "topic:drinks"}
+		switch i % 3 {
+		case 0:
+			item.Context = NullableOf("support chat")
+			item.DocumentId = Null[string]()
+		case 1:
+			scopes := [][]string{{"user:alice"}}
+			item.ObservationScopes = NullableOf(ObservationScopes{ArrayOfArrayOfString: &scopes})
+		}
+		items[i] = *item
+	}
+	async := true
+	return RetainRequest{Items: items, Async: &async, DocumentTags: []string{"batch"}}
+}
+
+func sampleRecallResponse(n int) RecallResponse {
+	resp := RecallResponse{
+		Trace: map[string]interface{}{
+			"query":  "drinks",
+			"steps":  []interface{}{1.0, "two", nil, true},
+			"scores": map[string]interface{}{"tiny": 1e-7, "huge": 1e21, "quarter": 0.25, "neg": -3.0},
+		},
+		Entities:    map[string]EntityStateResponse{},
+		Chunks:      map[string]ChunkData{},
+		SourceFacts: map[string]RecallResult{},
+	}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("fact-%d", i)
+		r := RecallResult{
+			Id:            id,
+			Text:          fmt.Sprintf("Alice prefers tea (%d)", i),
+			Type:          NullableOf(FactTypeWorld),
+			Entities:      []string{"Alice"},
+			OccurredStart: NullableOf("2024-01-02T03:04:05Z"),
+			OccurredEnd:   Null[string](),
+			Metadata:      map[string]string{"k": "v"},
+			ChunkId:       NullableOf("chunk-" + id),
+			Tags:          []string{"user:alice"},
+			SourceFactIds: []string{"src-" + id},
+		}
+		resp.Results = append(resp.Results, r)
+		resp.SourceFacts["src-"+id] = RecallResult{Id: "src-" + id, Text: "source"}
+		truncated := i%2 == 0
+		resp.Chunks["chunk-"+id] = ChunkData{Id: "chunk-" + id, Text: strings.Repeat("chunk text ", 20), ChunkIndex: int32(i), Truncated: &truncated}
+	}
+	resp.Entities["alice"] = EntityStateResponse{
+		EntityId:      "alice",
+		CanonicalName: "Alice",
+		Observations:  []EntityObservationResponse{{Text: "likes tea", MentionedAt: NullableOf("2024-01-02")}},
+	}
+	return resp
+}
+
+func TestMarshalModelMatchesToMap(t *testing.T) {
+	maxTokens := int32(512)
+	budget := HIGH
+	bank := CreateBankRequest{Name: NullableOf("Support bot"), Mission: Null[string]()}
+	recall := NewRecallRequest("What does Alice drink?")
+	recall.MaxTokens = &maxTokens
+	recall.Budget = &budget
+	recall.Types = []FactType{FactTypeWorld, FactTypeExperience}
+	recall.QueryTimestamp = Null[string]()
+
+	retain := sampleRetainRequest(4)
+	retain.Items[2].Content = "tabs\t, quotes \" \\ <b>&</b> \b\f\x01 \u2028\u2029 \xff é 🍵"
+	resp := sampleRecallResponse(3)
+	empty := RecallResponse{}
+	field, index := "query", int32(0)
+	validation := HTTPValidationError{Detail: []ValidationError{
+		*NewValidationError([]ValidationErrorLocInner{{String: &field}, {Int32: &index}}, "bad", "value_error"),
+	}}
+	for name, m := range map[string]MappedNullable{
+		"RetainRequest":       retain,
+		"MemoryItem":          retain.Items[1],
+		"RecallResponse":      resp,
+		"RecallResponse{}":    empty,
+		"RecallRequest":       *recall,
+		"CreateBankRequest":   bank,
+		"CreateBankRequest0":  CreateBankRequest{},
+		"HTTPValidationError": validation,
+	} {
+		want, err := legacyMarshal(m)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s:\n got %s\nwant %s", name, got, want)
+		}
+	}
+}
+
+// codecModels holds every generated model; TestCodecModelsComplete checks it
+// against the model files.
+var codecModels = []MappedNullable{
+	AddBackgroundRequest{}, AsyncOperationSubmitResponse{}, BackgroundResponse{},
+	BankConfigResponse{}, BankConfigUpdate{}, BankListItem{}, BankListResponse{},
+	BankProfileResponse{}, BankStatsResponse{}, CancelOperationResponse{},
+	ChildOperationStatus{}, ChunkData{}, ChunkIncludeOptions{}, ChunkResponse{},
+	ClearMemoryObservationsResponse{}, ConsolidationResponse{}, CreateBankRequest{},
+	CreateDirectiveRequest{}, CreateMentalModelRequest{}, CreateMentalModelResponse{},
+	DeleteDocumentResponse{}, DeleteResponse{}, DirectiveListResponse{},
+	DirectiveResponse{}, DispositionTraits{}, DocumentResponse{}, EntityDetailResponse{},
+	EntityIncludeOptions{}, EntityInput{}, EntityListItem{}, EntityListResponse{},
+	EntityObservationResponse{}, EntityStateResponse{}, FeaturesInfo{},
+	FileRetainResponse{}, GraphDataResponse{}, HTTPValidationError{}, IncludeOptions{},
+	ListDocumentsResponse{}, ListMemoryUnitsResponse{}, ListTagsResponse{}, MemoryItem{},
+	MentalModelListResponse{}, MentalModelResponse{}, MentalModelTrigger{},
+	OperationResponse{}, OperationStatusResponse{}, OperationsListResponse{},
+	RecallRequest{}, RecallResponse{}, RecallResult{}, ReflectBasedOn{}, ReflectDirective{},
+	ReflectFact{}, ReflectIncludeOptions{}, ReflectLLMCall{}, ReflectMentalModel{},
+	ReflectRequest{}, ReflectResponse{}, ReflectToolCall{}, ReflectTrace{}, RetainRequest{},
+	RetainResponse{}, SourceFactsIncludeOptions{}, TagItem{}, TokenUsage{},
+	ToolCallsIncludeOptions{}, UpdateDirectiveRequest{}, UpdateDispositionRequest{},
+	UpdateMentalModelRequest{}, ValidationError{}, VersionResponse{},
+}
+
+func TestCodecModelsComplete(t *testing.T) {
+	files, err := filepath.Glob("model_*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{}
+	decl := regexp.MustCompile(`var _ MappedNullable = &(\w+)\{\}`)
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range decl.FindAllSubmatch(src, -1) {
+			want[string(m[1])] = true
+		}
+	}
+	for _, m := range codecModels {
+		delete(want, reflect.TypeOf(m).Name())
+	}
+	for name := range want {
+		t.Errorf("%s is missing from codecModels", name)
+	}
+}
+
+// TestMarshalModelRandomized compares marshalModel with the ToMap encoding
+// for randomly filled values of every model, so the two cannot drift apart
+// when the generator changes how ToMap omits or encodes a field.
+func TestMarshalModelRandomized(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range codecModels {
+		typ := reflect.TypeOf(m)
+		for i := 0; i < 200; i++ {
+			v := reflect.New(typ).Elem()
+			fillRandom(rnd, v, 0)
+			model := v.Interface().(MappedNullable)
+			want, wantErr := legacyMarshal(model)
+			got, err := json.Marshal(model)
+			if (err != nil) != (wantErr != nil) || !bytes.Equal(got, want) {
+				t.Fatalf("%s:\n got %s (%v)\nwant %s (%v)", typ.Name(), got, err, want, wantErr)
+			}
+		}
+	}
+}
+
+// randomStrings include characters that are escaped in JSON and keys whose
+// quoted order differs from their raw order.
+var randomStrings = []string{"", "a", "a!", "a\"", "a#", "A", "é", "<b>&</b>", "tab\t", "\u2028", "\xff", "🍵", "world"}
+
+// fillRandom sets v to a random value, leaving optional values unset now and
+// then. Nesting stops at a small depth so recursive types terminate.
+func fillRandom(rnd *rand.Rand, v reflect.Value, depth int) {
+	t := v.Type()
+	if isNullableType(t) {
+		switch rnd.Intn(3) {
+		case 0:
+			v.Addr().MethodByName("SetNull").Call(nil)
+		case 1:
+			sf, _ := t.FieldByName("value")
+			val := reflect.New(sf.Type.Elem())
+			fillRandom(rnd, val.Elem(), depth+1)
+			v.Addr().MethodByName("Set").Call([]reflect.Value{val})
+		}
+		return
+	}
+	if t == timeType {
+		v.Set(reflect.ValueOf(time.Unix(rnd.Int63n(1<<32), rnd.Int63n(1e9)).UTC()))
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				fillRandom(rnd, v.Field(i), depth+1)
+			}
+		}
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		if depth > 4 || rnd.Intn(4) == 0 {
+			return
+		}
+		switch t.Kind() {
+		case reflect.Ptr:
+			v.Set(reflect.New(t.Elem()))
+			fillRandom(rnd, v.Elem(), depth+1)
+		case reflect.Slice:
+			n := rnd.Intn(3)
+			v.Set(reflect.MakeSlice(t, n, n))
+			for i := 0; i < n; i++ {
+				fillRandom(rnd, v.Index(i), depth+1)
+			}
+		case reflect.Map:
+			v.Set(reflect.MakeMap(t))
+			for i := rnd.Intn(4); i > 0; i-- {
+				k, e := reflect.New(t.Key()).Elem(), reflect.New(t.Elem()).Elem()
+				fillRandom(rnd, k, depth+1)
+				fillRandom(rnd, e, depth+1)
+				v.SetMapIndex(k, e)
+			}
+		case reflect.Interface:
+			if x := randomJSON(rnd, depth); x != nil {
+				v.Set(reflect.ValueOf(x))
+			}
+		}
+	case reflect.String:
+		v.SetString(randomStrings[rnd.Intn(len(randomStrings))])
+	case reflect.Bool:
+		v.SetBool(rnd.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(rnd.Int63n(2001) - 1000)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(randomFloat(rnd))
+	}
+}
+
+// randomJSON returns a value as encoding/json decodes it into an
+// interface{}.
+func randomJSON(rnd *rand.Rand, depth int) interface{} {
+	n := 4
+	if depth < 4 {
+		n = 6
+	}
+	switch rnd.Intn(n) {
+	case 0:
+		return nil
+	case 1:
+		return randomStrings[rnd.Intn(len(randomStrings))]
+	case 2:
+		return rnd.Intn(2) == 0
+	case 3:
+		return randomFloat(rnd)
+	case 4:
+		out := make([]interface{}, rnd.Intn(3))
+		for i := range out {
+			out[i] = randomJSON(rnd, depth+1)
+		}
+		return out
+	}
+	out := map[string]interface{}{}
+	for i := rnd.Intn(3); i > 0; i-- {
+		out[randomStrings[rnd.Intn(len(randomStrings))]] = randomJSON(rnd, depth+1)
+	}
+	return out
+}
+
+func randomFloat(rnd *rand.Rand) float64 {
+	floats := []float64{0, 0.25, -3, 1e-7, 1e21, 123456.789}
+	if rnd.Intn(2) == 0 {
+		return floats[rnd.Intn(len(floats))]
+	}
+	return rnd.NormFloat64() * math.Pow(10, float64(rnd.Intn(40)-20))
+}
+
+func TestMarshalModelKeyOrder(t *testing.T) {
+	// encoding/json sorts map keys by their raw bytes; "a!" sorts after
+	// "a" even though its quoted form sorts before it.
+	v := struct {
+		Bang  int `json:"a!"`
+		Plain int `json:"a"`
+	}{1, 2}
+	got, err := appendModel(nil, reflect.ValueOf(v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `{"a":2,"a!":1}` {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestUnmarshalRequiredProperties(t *testing.T) {
+	for _, tc := range []struct {
+		data, err string
+	}{
+		{`{"content":"tea"}`, ""},
+		{` { "tags" : ["a", "}"], "meta\u0064ata": {"content": "x"}, "content" : "tea" } `, ""},
+		{`{"tags":["content"],"metadata":{"content":"x"}}`, "no value given for required property content"},
+		{`{"cont\u0065nt":"tea"}`, ""},
+		{`{}`, "no value given for required property content"},
+		{`null`, "no value given for required property content"},
+		{`{"content":`, "unexpected end of JSON input"},
+		{`["content"]`, "json: cannot unmarshal array into Go value of type map[string]interface {}"},
+	} {
+		var item MemoryItem
+		err := json.Unmarshal([]byte(tc.data), &item)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.err {
+			t.Errorf("%s: got error %q, want %q", tc.data, got, tc.err)
+		}
+	}
+
+	var item MemoryItem
+	if err := json.Unmarshal([]byte(`{"content":"tea","bogus":1}`), &item); err == nil {
+		t.Error("unknown fields must still be rejected")
+	}
+}
+
+func TestRecallResponseRoundTrip(t *testing.T) {
+	want := sampleRecallResponse(5)
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got RecallResponse
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	again, _ := json.Marshal(got)
+	if !bytes.Equal(again, data) {
+		t.Fatalf("round trip changed the encoding:\n got %s\nwant %s", again, data)
+	}
+}
+
+func BenchmarkMarshalRetainRequest(b *testing.B) {
+	req := sampleRetainRequest(1000)
+	b.Run("ToMap", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := legacyMarshal(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Direct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkUnmarshalRecallResponse(b *testing.B) {
+	data, err := json.Marshal(sampleRecallResponse(1000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("GenericMap", func(b *testing.B) {
+		// The extra pass the generated code used to make for each object
+		// before decoding it.
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			var all map[string]interface{}
+			if err := json.Unmarshal(data, &all); err != nil {
+				b.Fatal(err)
+			}
+			var resp RecallResponse
+			if err := json.Unmarshal(data, &resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Direct", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			var resp RecallResponse
+			if err := json.Unmarshal(data, &resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,221 @@
+package hindsight
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// CompressionConfig configures CompressionMiddleware. Zero values select the
+// documented defaults.
+type CompressionConfig struct {
+	// MinSize is the smallest request body, in bytes, that is compressed.
+	// Defaults to 1 KiB; small bodies are not worth the CPU.
+	MinSize int64
+	// Level is the gzip compression level. Defaults to gzip.DefaultCompression.
+	Level int
+}
+
+// CompressionMiddleware gzips request bodies of at least MinSize bytes and
+// asks the server for gzip responses, decoding them before they reach outer
+// middleware such as MaxResponseSizeMiddleware.
+//
+// The Hindsight API server neither decodes compressed request bodies nor
+// compresses its responses, so the middleware only saves bandwidth behind a
+// reverse proxy or gateway that does. Against the server itself, the first
+// compressed body fails to parse and is sent again uncompressed, after which
+// the host is remembered and later requests to it are not compressed.
+//
+// A body counts as rejected on 415 Unsupported Media Type, or on a 400 or
+// 422 whose body reports a JSON decode failure as FastAPI words it. Other
+// 400 and 422 responses are returned as they are, so an invalid request is
+// not sent twice.
+func CompressionMiddleware(cfg CompressionConfig) Middleware {
+	if cfg.MinSize <= 0 {
+		cfg.MinSize = 1024
+	}
+	if cfg.Level == 0 {
+		cfg.Level = gzip.DefaultCompression
+	}
+	c := &compressor{cfg: cfg, rejected: make(map[string]bool)}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return c.roundTrip(next, req)
+		})
+	}
+}
+
+type compressor struct {
+	cfg     CompressionConfig
+	writers sync.Pool
+
+	mu       sync.Mutex
+	rejected map[string]bool // hosts that do not accept gzip bodies
+}
+
+func (c *compressor) roundTrip(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if out.Header.Get("Accept-Encoding") == "" {
+		out.Header.Set("Accept-Encoding", "gzip")
+	}
+	compressed, err := c.compress(out)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next.RoundTrip(out)
+	if err == nil && compressed && rejectsEncoding(resp) {
+		plain := req.Clone(req.Context())
+		if plain.Body, err = req.GetBody(); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		if plain.Header.Get("Accept-Encoding") == "" {
+			plain.Header.Set("Accept-Encoding", "gzip")
+		}
+		first := resp
+		resp, err = next.RoundTrip(plain)
+		if err != nil {
+			// Report the server's rejection rather than the retry's failure.
+			resp, err = first, nil
+		} else {
+			io.Copy(io.Discard, io.LimitReader(first.Body, 64*1024))
+			first.Body.Close()
+			if !rejectsEncoding(resp) {
+				c.mu.Lock()
+				c.rejected[req.URL.Host] = true
+				c.mu.Unlock()
+			}
+		}
+	}
+	if err != nil {
+		return resp, err
+	}
+	decodeContentEncoding(resp)
+	return resp, nil
+}
+
+// compress replaces the body of req, a clone the caller owns, with its gzip
+// encoding when that is worthwhile. Bodies that cannot be replayed are left
+// alone, since an uncompressed fallback would need them again.
+func (c *compressor) compress(req *http.Request) (bool, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil ||
+		req.ContentLength < c.cfg.MinSize || req.Header.Get("Content-Encoding") != "" {
+		return false, nil
+	}
+	c.mu.Lock()
+	rejected := c.rejected[req.URL.Host]
+	c.mu.Unlock()
+	if rejected {
+		return false, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return false, err
+	}
+	defer body.Close()
+	var buf bytes.Buffer
+	zw, _ := c.writers.Get().(*gzip.Writer)
+	if zw == nil {
+		if zw, err = gzip.NewWriterLevel(&buf, c.cfg.Level); err != nil {
+			return false, err
+		}
+	} else {
+		zw.Reset(&buf)
+	}
+	defer c.writers.Put(zw)
+	if _, err := io.Copy(zw, body); err != nil {
+		return false, err
+	}
+	if err := zw.Close(); err != nil {
+		return false, err
+	}
+	if int64(buf.Len()) >= req.ContentLength {
+		return false, nil
+	}
+
+	req.Body.Close()
+	data := buf.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(data)), nil }
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Encoding", "gzip")
+	return true, nil
+}
+
+// decodeFailures are the messages FastAPI responds with when a body cannot
+// be decoded as JSON: a 422 "json_invalid" error, or a 400 for bodies that
+// are not valid text.
+var decodeFailures = [][]byte{
+	[]byte(`"json_invalid"`),
+	[]byte("JSON decode error"),
+	[]byte("There was an error parsing the body"),
+}
+
+// rejectsEncoding reports whether resp is the server failing to read a
+// compressed body. It peeks at the body of a 400 or 422 and leaves it
+// readable.
+func rejectsEncoding(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnsupportedMediaType:
+		return true
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+	default:
+		return false
+	}
+	if resp.Body == nil {
+		return false
+	}
+	head, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	resp.Body = readCloser{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	for _, msg := range decodeFailures {
+		if bytes.Contains(head, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeContentEncoding replaces a gzip-encoded response body with its
+// decoded content. Go's transport does this itself only when it added the
+// Accept-Encoding header; custom transports, and requests that set the
+// header explicitly, leave it to the caller.
+func decodeContentEncoding(resp *http.Response) {
+	if resp == nil || resp.Body == nil || resp.Body == http.NoBody ||
+		!strings.EqualFold(strings.TrimSpace(resp.Header.Get("Content-Encoding")), "gzip") {
+		return
+	}
+	resp.Body = &gzipBody{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// gzipBody decompresses body, reading the gzip header on first use so that
+// an empty body (as for HEAD or 204 responses) reads as empty.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if b.zr == nil {
+		if b.zr, b.err = gzip.NewReader(b.body); b.err != nil {
+			return 0, b.err
+		}
+	}
+	return b.zr.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,147 @@
+package hindsight
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func gzipBytes(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	io.WriteString(zw, s)
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	var mu sync.Mutex
+	var encodings []string
+	acceptGzip, invalid := true, false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		accept, reject := acceptGzip, invalid
+		mu.Unlock()
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			if !accept {
+				// As FastAPI answers a body it cannot decode.
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				io.WriteString(w, `{"detail":[{"type":"json_invalid","loc":["body",0],"msg":"JSON decode error","input":{}}]}`)
+				return
+			}
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			body = zr
+		}
+		if _, err := io.ReadAll(body); err != nil {
+			t.Error(err)
+		}
+		if reject {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			io.WriteString(w, `{"detail":[{"type":"missing","loc":["body","items"],"msg":"Field required"}]}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		var out string
+		if strings.HasSuffix(r.URL.Path, "/recall") {
+			out = `{"results":[{"id":"m1","text":"` + strings.Repeat("tea ", 500) + `"}]}`
+		} else {
+			out = `{"success":true,"bank_id":"agent","items_count":50,"async":false}`
+		}
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(gzipBytes(t, out))
+			return
+		}
+		io.WriteString(w, out)
+	}))
+	defer srv.Close()
+
+	client, err := New(WithBaseURL(srv.URL), WithCompression(CompressionConfig{MinSize: 512}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bank := client.Bank("agent")
+	ctx := context.Background()
+	items := make([]MemoryItem, 50)
+	for i := range items {
+		items[i] = *NewMemoryItem("Alice talked about her preference for green tea over coffee")
+	}
+
+	if _, err := bank.Retain(ctx, items...); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := bank.Recall(ctx, "tea")
+	if err != nil || len(resp.Results) != 1 || len(resp.Results[0].Text) != 2000 {
+		t.Fatalf("Recall = %+v, %v", resp, err)
+	}
+	if strings.Join(encodings, ",") != "gzip," {
+		t.Fatalf("request encodings = %q, want the large retain compressed and the small recall not", encodings)
+	}
+
+	// A server that rejects gzip bodies gets the request again uncompressed,
+	// and later requests are not compressed at all.
+	client, _ = New(WithBaseURL(srv.URL), WithCompression(CompressionConfig{MinSize: 512}))
+	bank = client.Bank("agent")
+	mu.Lock()
+	acceptGzip = false
+	encodings = nil
+	mu.Unlock()
+	for i := 0; i < 2; i++ {
+		if _, err := bank.Retain(ctx, items...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if strings.Join(encodings, ",") != "gzip,," {
+		t.Fatalf("request encodings = %q", encodings)
+	}
+
+	// A compressed request that is invalid for other reasons is not resent.
+	client, _ = New(WithBaseURL(srv.URL), WithCompression(CompressionConfig{MinSize: 512}))
+	mu.Lock()
+	acceptGzip, invalid = true, true
+	encodings = nil
+	mu.Unlock()
+	_, err = client.Bank("agent").Retain(ctx, items...)
+	var apiErr *GenericOpenAPIError
+	if !errors.As(err, &apiErr) || !strings.Contains(string(apiErr.Body()), "Field required") {
+		t.Fatalf("err = %v", err)
+	}
+	if strings.Join(encodings, ",") != "gzip" {
+		t.Fatalf("request encodings = %q", encodings)
+	}
+}
+
+func TestCompressedResponseWithCustomHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(gzipBytes(t, `{"results":[{"id":"m1","text":"Alice prefers tea"}]}`))
+	}))
+	defer srv.Close()
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	cfg.HTTPClient = &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	resp, _, err := NewAPIClient(cfg).MemoryAPI.RecallMemories(context.Background(), "agent").
+		RecallRequest(*NewRecallRequest("tea")).Execute()
+	if err != nil || resp.Results[0].Text != "Alice prefers tea" {
+		t.Fatalf("Execute = %+v, %v", resp, err)
+	}
+}
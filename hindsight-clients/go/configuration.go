@@ -77,6 +77,8 @@ type Configuration struct {
 	DefaultHeader    map[string]string `json:"defaultHeader,omitempty"`
 	UserAgent        string            `json:"userAgent,omitempty"`
 	Debug            bool              `json:"debug,omitempty"`
+	// ValidateRequests validates request bodies before they are sent; see RecallRequest.Validate.
+	ValidateRequests bool              `json:"validateRequests,omitempty"`
 	Servers          ServerConfigurations
 	OperationServers map[string]ServerConfigurations
 	HTTPClient       *http.Client
@@ -100,9 +102,16 @@ func NewConfiguration() *Configuration {
 	return cfg
 }
 
-// AddDefaultHeader adds a new HTTP header to the default header in the request
+// AddDefaultHeader adds a new HTTP header to the default header in the request.
+// It replaces DefaultHeader with an updated copy instead of writing to it, so
+// configurations sharing the map, such as struct copies, are not affected.
 func (c *Configuration) AddDefaultHeader(key string, value string) {
-	c.DefaultHeader[key] = value
+	header := make(map[string]string, len(c.DefaultHeader)+1)
+	for k, v := range c.DefaultHeader {
+		header[k] = v
+	}
+	header[key] = value
+	c.DefaultHeader = header
 }
 
 // URL formats template on a index using given variables
@@ -0,0 +1,115 @@
+package hindsight
+
+// Clone returns a deep copy of c that can be changed without affecting c or
+// any client built from it: the default headers, servers and their
+// variables, and operation servers are all copied. The HTTP client, and
+// with it the transport, middleware and connection pool, is shared.
+func (c *Configuration) Clone() *Configuration {
+	out := *c
+	out.DefaultHeader = make(map[string]string, len(c.DefaultHeader))
+	for k, v := range c.DefaultHeader {
+		out.DefaultHeader[k] = v
+	}
+	out.Servers = cloneServers(c.Servers)
+	out.OperationServers = make(map[string]ServerConfigurations, len(c.OperationServers))
+	for k, v := range c.OperationServers {
+		out.OperationServers[k] = cloneServers(v)
+	}
+	return &out
+}
+
+func cloneServers(servers ServerConfigurations) ServerConfigurations {
+	if servers == nil {
+		return nil
+	}
+	out := make(ServerConfigurations, len(servers))
+	for i, server := range servers {
+		out[i] = server
+		if server.Variables == nil {
+			continue
+		}
+		out[i].Variables = make(map[string]ServerVariable, len(server.Variables))
+		for name, variable := range server.Variables {
+			variable.EnumValues = append([]string(nil), variable.EnumValues...)
+			out[i].Variables[name] = variable
+		}
+	}
+	return out
+}
+
+// WithHeader returns a copy of c that sends key: value with every request.
+// c itself is not modified.
+func (c *Configuration) WithHeader(key, value string) *Configuration {
+	out := c.Clone()
+	out.AddDefaultHeader(key, value)
+	return out
+}
+
+// WithServer returns a copy of c that sends requests to url instead of the
+// configured servers. Operation-specific servers are kept. c itself is not
+// modified.
+func (c *Configuration) WithServer(url string) *Configuration {
+	out := c.Clone()
+	out.Servers = ServerConfigurations{{URL: url}}
+	return out
+}
+
+// WithToken returns a copy of c that authenticates with the bearer token
+// instead of c's Authorization header or TokenSource. c itself is not
+// modified.
+func (c *Configuration) WithToken(token string) *Configuration {
+	return c.WithTokenSource(StaticTokenSource(token))
+}
+
+// WithTokenSource returns a copy of c that authenticates with ts instead of
+// c's Authorization header or TokenSource. c itself is not modified.
+func (c *Configuration) WithTokenSource(ts TokenSource) *Configuration {
+	out := c.Clone()
+	out.SetTokenSource(ts)
+	return out
+}
+
+// WithUserAgent returns a copy of c that sends ua as its User-Agent. c
+// itself is not modified.
+func (c *Configuration) WithUserAgent(ua string) *Configuration {
+	out := c.Clone()
+	out.UserAgent = ua
+	return out
+}
+
+// The APIClient methods below derive a client from c without modifying it.
+// Derived clients are cheap: they share c's HTTP client, transport and
+// connection pool, so a gateway can create one per tenant or per request
+// and drop it when done. Each gets its own deep copy of the Configuration,
+// so modifying one through GetConfig does not affect c or its siblings.
+//
+// The Configuration of a client in use, c's included, is frozen: derivation
+// reads it concurrently with calls, so it must not be modified after
+// NewAPIClient. Derive a new client to change a setting instead.
+
+// WithHeader returns a client that also sends key: value with every request.
+func (c *APIClient) WithHeader(key, value string) *APIClient {
+	return NewAPIClient(c.cfg.WithHeader(key, value))
+}
+
+// WithServer returns a client that sends requests to url. A unix:// URL
+// needs UnixSocketMiddleware on c, since derived clients add no transports
+// of their own.
+func (c *APIClient) WithServer(url string) *APIClient {
+	return NewAPIClient(c.cfg.WithServer(url))
+}
+
+// WithToken returns a client that authenticates with the bearer token.
+func (c *APIClient) WithToken(token string) *APIClient {
+	return NewAPIClient(c.cfg.WithToken(token))
+}
+
+// WithTokenSource returns a client that authenticates with ts.
+func (c *APIClient) WithTokenSource(ts TokenSource) *APIClient {
+	return NewAPIClient(c.cfg.WithTokenSource(ts))
+}
+
+// WithUserAgent returns a client that sends ua as its User-Agent.
+func (c *APIClient) WithUserAgent(ua string) *APIClient {
+	return NewAPIClient(c.cfg.WithUserAgent(ua))
+}
@@ -0,0 +1,132 @@
+package hindsight
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDerivedClients(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]string{}
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Header.Get("X-Gateway-Tenant")] = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"bank_id":"agent","name":"Agent","disposition":{"skepticism":3,"literalism":3,"empathy":3},"mission":""}`)
+	}))
+	srv.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: "http://unused.invalid"}}
+	cfg.SetTokenSource(StaticTokenSource("parent-token"))
+	parent := NewAPIClient(cfg).WithServer(srv.URL)
+	ctx := context.Background()
+
+	// Derived clients are created concurrently with calls on the parent,
+	// and none of their settings leak into the parent or each other.
+	var wg sync.WaitGroup
+	for _, tenant := range []string{"a", "b", "c", "d"} {
+		wg.Add(2)
+		go func(tenant string) {
+			defer wg.Done()
+			client := parent.WithHeader("X-Gateway-Tenant", tenant).WithToken("token-" + tenant)
+			if _, _, err := client.BanksAPI.GetBankProfile(ctx, "agent").Execute(); err != nil {
+				t.Error(err)
+			}
+		}(tenant)
+		go func() {
+			defer wg.Done()
+			if _, _, err := parent.BanksAPI.GetBankProfile(ctx, "agent").Execute(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := map[string]string{
+		"":  "Bearer parent-token",
+		"a": "Bearer token-a",
+		"b": "Bearer token-b",
+		"c": "Bearer token-c",
+		"d": "Bearer token-d",
+	}
+	for k, v := range want {
+		if seen[k] != v {
+			t.Fatalf("Authorization by tenant = %v, want %v", seen, want)
+		}
+	}
+	if len(cfg.DefaultHeader) != 0 || cfg.Servers[0].URL != "http://unused.invalid" {
+		t.Fatalf("parent configuration was modified: %+v", cfg)
+	}
+
+	// Short-lived derived clients reuse the parent's connection pool.
+	before := atomic.LoadInt32(&conns)
+	for i := 0; i < 50; i++ {
+		if _, _, err := parent.WithToken("short-lived").BanksAPI.GetBankProfile(ctx, "agent").Execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(&conns) - before; n > 1 {
+		t.Fatalf("50 derived clients opened %d connections", n)
+	}
+}
+
+func TestDerivedClientsIsolated(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"bank_id":"agent","name":"Agent","disposition":{"skepticism":3,"literalism":3,"empathy":3},"mission":""}`)
+	}))
+	defer srv.Close()
+	host, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{
+		URL:       "http://" + host + ":{port}",
+		Variables: map[string]ServerVariable{"port": {DefaultValue: port, EnumValues: []string{port}}},
+	}}
+	cfg.OperationServers = map[string]ServerConfigurations{"BanksAPIService.GetBankProfile": cfg.Servers}
+	parent := NewAPIClient(cfg)
+	a, b := parent.WithHeader("X-Client", "a"), parent.WithHeader("X-Client", "b")
+	ctx := context.Background()
+
+	// Modifying a while b is in use must not race with b's requests (run
+	// with -race) or change where they go.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			if _, _, err := b.BanksAPI.GetBankProfile(ctx, "agent").Execute(); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		ac := a.GetConfig()
+		ac.AddDefaultHeader("X-Client", "changed")
+		ac.Servers[0].Variables["port"] = ServerVariable{DefaultValue: "1"}
+		ac.OperationServers["BanksAPIService.GetBankProfile"][0].Variables["port"] = ServerVariable{DefaultValue: "1"}
+	}
+	<-done
+
+	if got := b.GetConfig().DefaultHeader["X-Client"]; got != "b" {
+		t.Fatalf("b's header = %q", got)
+	}
+	if got := cfg.OperationServers["BanksAPIService.GetBankProfile"][0].Variables["port"].DefaultValue; got != port || len(cfg.DefaultHeader) != 0 {
+		t.Fatalf("parent was modified: port %q, headers %v", got, cfg.DefaultHeader)
+	}
+}
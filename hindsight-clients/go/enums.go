@@ -0,0 +1,143 @@
+package hindsight
+
+// The string enums below are closed sets in the server's schema but plain
+// strings in the OpenAPI document, so they are declared here rather than
+// generated. Unlike the generated enums such as Budget, they decode any
+// value: a value added by a newer server is kept as-is and reported by
+// IsValid as unknown instead of failing the whole response.
+//
+// A NullableX alias is declared for the enums that a model holds in a
+// nullable field, FactType and RetainExtractionMode, as the generator does
+// for its own types.
+
+// FactType is the kind of a memory: "world" facts about others, the bank's
+// own "experience", and "observation"s consolidated from both.
+type FactType string
+
+// List of FactType
+const (
+	FactTypeWorld       FactType = "world"
+	FactTypeExperience  FactType = "experience"
+	FactTypeObservation FactType = "observation"
+)
+
+// AllowedFactTypeEnumValues lists the known FactType values.
+var AllowedFactTypeEnumValues = []FactType{
+	FactTypeWorld,
+	FactTypeExperience,
+	FactTypeObservation,
+}
+
+// IsValid reports whether v is a known fact type.
+func (v FactType) IsValid() bool {
+	return enumContains(AllowedFactTypeEnumValues, v)
+}
+
+// Ptr returns a reference to v.
+func (v FactType) Ptr() *FactType {
+	return &v
+}
+
+type NullableFactType = Nullable[FactType]
+
+// OperationStatus is the state of an async operation.
+type OperationStatus string
+
+// List of OperationStatus
+const (
+	OperationStatusPending   OperationStatus = "pending"
+	OperationStatusCompleted OperationStatus = "completed"
+	OperationStatusFailed    OperationStatus = "failed"
+	// OperationStatusNotFound is reported for unknown operation IDs. It is
+	// not accepted as a ListOperations filter.
+	OperationStatusNotFound OperationStatus = "not_found"
+)
+
+// AllowedOperationStatusEnumValues lists the known OperationStatus values.
+var AllowedOperationStatusEnumValues = []OperationStatus{
+	OperationStatusPending,
+	OperationStatusCompleted,
+	OperationStatusFailed,
+	OperationStatusNotFound,
+}
+
+// IsValid reports whether v is a known operation status.
+func (v OperationStatus) IsValid() bool {
+	return enumContains(AllowedOperationStatusEnumValues, v)
+}
+
+// Ptr returns a reference to v.
+func (v OperationStatus) Ptr() *OperationStatus {
+	return &v
+}
+
+// TagsMatch is how recall and reflect match a memory's tags against the
+// requested tags. The strict variants also exclude untagged memories.
+type TagsMatch string
+
+// List of TagsMatch
+const (
+	TagsMatchAny       TagsMatch = "any"
+	TagsMatchAll       TagsMatch = "all"
+	TagsMatchAnyStrict TagsMatch = "any_strict"
+	TagsMatchAllStrict TagsMatch = "all_strict"
+)
+
+// AllowedTagsMatchEnumValues lists the known TagsMatch values.
+var AllowedTagsMatchEnumValues = []TagsMatch{
+	TagsMatchAny,
+	TagsMatchAll,
+	TagsMatchAnyStrict,
+	TagsMatchAllStrict,
+}
+
+// IsValid reports whether v is a known tags match mode.
+func (v TagsMatch) IsValid() bool {
+	return enumContains(AllowedTagsMatchEnumValues, v)
+}
+
+// Ptr returns a reference to v.
+func (v TagsMatch) Ptr() *TagsMatch {
+	return &v
+}
+
+// RetainExtractionMode is how retain extracts facts from content.
+type RetainExtractionMode string
+
+// List of RetainExtractionMode
+const (
+	RetainExtractionModeConcise RetainExtractionMode = "concise"
+	RetainExtractionModeVerbose RetainExtractionMode = "verbose"
+	// RetainExtractionModeCustom uses the bank's retain custom
+	// instructions as the extraction prompt.
+	RetainExtractionModeCustom RetainExtractionMode = "custom"
+)
+
+// AllowedRetainExtractionModeEnumValues lists the known
+// RetainExtractionMode values.
+var AllowedRetainExtractionModeEnumValues = []RetainExtractionMode{
+	RetainExtractionModeConcise,
+	RetainExtractionModeVerbose,
+	RetainExtractionModeCustom,
+}
+
+// IsValid reports whether v is a known extraction mode.
+func (v RetainExtractionMode) IsValid() bool {
+	return enumContains(AllowedRetainExtractionModeEnumValues, v)
+}
+
+// Ptr returns a reference to v.
+func (v RetainExtractionMode) Ptr() *RetainExtractionMode {
+	return &v
+}
+
+type NullableRetainExtractionMode = Nullable[RetainExtractionMode]
+
+func enumContains[T comparable](values []T, v T) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package hindsight
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEnums(t *testing.T) {
+	for _, v := range AllowedFactTypeEnumValues {
+		if !v.IsValid() {
+			t.Errorf("%q is not valid", v)
+		}
+	}
+	if FactType("episode").IsValid() || TagsMatch("exact").IsValid() || OperationStatus("").IsValid() {
+		t.Fatal("unknown value is valid")
+	}
+
+	// Values added by a newer server decode and round-trip unchanged.
+	var status OperationStatusResponse
+	if err := json.Unmarshal([]byte(`{"operation_id":"op","status":"cancelled"}`), &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.GetStatus() != "cancelled" || status.GetStatus().IsValid() {
+		t.Fatalf("status = %q", status.GetStatus())
+	}
+	var result RecallResult
+	if err := json.Unmarshal([]byte(`{"id":"1","text":"t","type":"episode"}`), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.GetType() != "episode" {
+		t.Fatalf("type = %q", result.GetType())
+	}
+
+	recall := NewRecallRequest("q")
+	RecallTypes(FactTypeWorld, FactTypeObservation)(recall)
+	RecallTags(TagsMatchAllStrict, "a")(recall)
+	body, err := json.Marshal(recall)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	json.Unmarshal(body, &got)
+	if types, _ := json.Marshal(got["types"]); string(types) != `["world","observation"]` || got["tags_match"] != "all_strict" {
+		t.Fatalf("body = %s", body)
+	}
+}
+
+func TestListTagsMatch(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query().Get("tags_match")
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"items":[]}`)
+	}))
+	defer srv.Close()
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	_, err := NewAPIClient(cfg).Bank("agent").Directives().List(context.Background(), ListTags(TagsMatchAnyStrict, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "any_strict" {
+		t.Fatalf("tags_match = %q", got)
+	}
+}
+
+func TestListMemoriesFactType(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query().Get("type")
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"items":[],"total":0,"limit":100,"offset":0}`)
+	}))
+	defer srv.Close()
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	_, _, err := NewAPIClient(cfg).MemoryAPI.ListMemories(context.Background(), "agent").Type_(FactTypeExperience).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "experience" {
+		t.Fatalf("type = %q", got)
+	}
+}
@@ -0,0 +1,329 @@
+package hindsight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ClientAPIVersion is the server API version this client was generated from.
+const ClientAPIVersion = "0.4.14"
+
+// The range of server API versions this client is known to work with:
+// MinSupportedAPIVersion inclusive, MaxSupportedAPIVersion exclusive.
+const (
+	MinSupportedAPIVersion = "0.4.0"
+	MaxSupportedAPIVersion = "0.5.0"
+)
+
+// ErrFeatureUnavailable is returned when a request needs a server feature
+// that the server reports as disabled. Use errors.Is to detect it.
+var ErrFeatureUnavailable = errors.New("hindsight: feature is not enabled on the server")
+
+// Feature names a server capability reported in VersionResponse.Features.
+type Feature string
+
+const (
+	FeatureObservations  Feature = "observations"
+	FeatureMCP           Feature = "mcp"
+	FeatureWorker        Feature = "worker"
+	FeatureBankConfigAPI Feature = "bank_config_api"
+	FeatureFileUploadAPI Feature = "file_upload_api"
+)
+
+// operationFeatures lists the operations the server rejects with a 404 when
+// the named feature is disabled.
+var operationFeatures = map[string]Feature{
+	"BanksAPIService.GetBankConfig":    FeatureBankConfigAPI,
+	"BanksAPIService.UpdateBankConfig": FeatureBankConfigAPI,
+	"BanksAPIService.ResetBankConfig":  FeatureBankConfigAPI,
+	"FilesAPIService.FileRetain":       FeatureFileUploadAPI,
+}
+
+// FeatureUnavailableError is the typed error returned for requests that need
+// a disabled feature. It matches ErrFeatureUnavailable with errors.Is.
+type FeatureUnavailableError struct {
+	Feature Feature
+	// Operation is the rejected operation, for example
+	// "FilesAPIService.FileRetain".
+	Operation string
+}
+
+func (e *FeatureUnavailableError) Error() string {
+	return fmt.Sprintf("%s: %s requires %q", ErrFeatureUnavailable, e.Operation, e.Feature)
+}
+
+// Is reports whether target is ErrFeatureUnavailable.
+func (e *FeatureUnavailableError) Is(target error) bool {
+	return target == ErrFeatureUnavailable
+}
+
+// Capabilities is what a server reported about itself through GetVersion.
+type Capabilities struct {
+	APIVersion string
+	Features   FeaturesInfo
+}
+
+// Has reports whether the feature is enabled. Unknown features report false.
+func (c *Capabilities) Has(f Feature) bool {
+	switch f {
+	case FeatureObservations:
+		return c.Features.Observations
+	case FeatureMCP:
+		return c.Features.Mcp
+	case FeatureWorker:
+		return c.Features.Worker
+	case FeatureBankConfigAPI:
+		return c.Features.BankConfigApi
+	case FeatureFileUploadAPI:
+		return c.Features.FileUploadApi
+	}
+	return false
+}
+
+// VersionMismatchError describes a server whose API version is outside the
+// range this client supports.
+type VersionMismatchError struct {
+	ServerVersion string
+}
+
+func (e *VersionMismatchError) Error() string {
+	return fmt.Sprintf("hindsight: server API version %s is outside the supported range [%s, %s) of this client (%s)",
+		e.ServerVersion, MinSupportedAPIVersion, MaxSupportedAPIVersion, ClientAPIVersion)
+}
+
+// CheckAPIVersion returns a *VersionMismatchError if version is outside
+// [MinSupportedAPIVersion, MaxSupportedAPIVersion), or an error if it cannot
+// be parsed.
+func CheckAPIVersion(version string) error {
+	v, err := parseAPIVersion(version)
+	if err != nil {
+		return err
+	}
+	lo, _ := parseAPIVersion(MinSupportedAPIVersion)
+	hi, _ := parseAPIVersion(MaxSupportedAPIVersion)
+	if compareAPIVersions(v, lo) < 0 || compareAPIVersions(v, hi) >= 0 {
+		return &VersionMismatchError{ServerVersion: version}
+	}
+	return nil
+}
+
+// parseAPIVersion parses "major.minor.patch", ignoring a leading "v" and any
+// pre-release or build suffix. Missing components are zero.
+func parseAPIVersion(s string) ([3]int, error) {
+	var v [3]int
+	trimmed := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(trimmed, "-+"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	parts := strings.Split(trimmed, ".")
+	if trimmed == "" || len(parts) > 3 {
+		return v, fmt.Errorf("hindsight: invalid API version %q", s)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("hindsight: invalid API version %q", s)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func compareAPIVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// FeatureNegotiatorConfig configures a FeatureNegotiator. Zero values select
+// the documented defaults.
+type FeatureNegotiatorConfig struct {
+	// TTL is how long fetched capabilities are cached. Defaults to 5 minutes.
+	TTL time.Duration
+	// OnVersionMismatch is called once per server whose API version is
+	// outside the supported range. Defaults to logging a warning with the
+	// standard logger.
+	OnVersionMismatch func(serverURL string, err error)
+}
+
+// FeatureNegotiator fetches each server's capabilities from GET /version on
+// first use, caches them, and rejects requests for disabled features with a
+// *FeatureUnavailableError instead of letting them fail with a 404.
+//
+// If the version endpoint cannot be reached, requests pass through unchanged
+// and the fetch is retried on a later request.
+type FeatureNegotiator struct {
+	cfg FeatureNegotiatorConfig
+	now func() time.Time
+
+	mu      sync.Mutex
+	servers map[string]*serverCapabilities
+}
+
+type serverCapabilities struct {
+	mu      sync.Mutex
+	caps    *Capabilities
+	fetched time.Time
+	warned  bool
+}
+
+// NewFeatureNegotiator creates a FeatureNegotiator. Install it with
+// Configuration.Use(n.Middleware()).
+func NewFeatureNegotiator(cfg FeatureNegotiatorConfig) *FeatureNegotiator {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 5 * time.Minute
+	}
+	if cfg.OnVersionMismatch == nil {
+		cfg.OnVersionMismatch = func(serverURL string, err error) {
+			log.Printf("%v (server %s)", err, serverURL)
+		}
+	}
+	return &FeatureNegotiator{
+		cfg:     cfg,
+		now:     time.Now,
+		servers: make(map[string]*serverCapabilities),
+	}
+}
+
+// Middleware returns the transport middleware that enforces feature checks.
+func (n *FeatureNegotiator) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			segments := splitPath(req)
+			_, op, offset, ok := matchRoute(req, segments)
+			if !ok {
+				return next.RoundTrip(req)
+			}
+			feature, gated := operationFeatures[op.Name]
+			if !gated {
+				return next.RoundTrip(req)
+			}
+			base := serverBaseURL(req, segments[:offset])
+			caps, err := n.capabilities(req.Context(), next, base, req.Header)
+			if err == nil && !caps.Has(feature) {
+				closeRequestBody(req)
+				return nil, &FeatureUnavailableError{Feature: feature, Operation: op.Name}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// Capabilities returns the cached capabilities of the server at serverURL,
+// fetching them with client if they are missing or stale. A nil client uses
+// http.DefaultClient.
+func (n *FeatureNegotiator) Capabilities(ctx context.Context, client *http.Client, serverURL string) (*Capabilities, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	rt := RoundTripperFunc(client.Do)
+	return n.capabilities(ctx, rt, strings.TrimRight(serverURL, "/"), nil)
+}
+
+// Invalidate drops all cached capabilities, for example after a server has
+// been reconfigured.
+func (n *FeatureNegotiator) Invalidate() {
+	n.mu.Lock()
+	n.servers = make(map[string]*serverCapabilities)
+	n.mu.Unlock()
+}
+
+func (n *FeatureNegotiator) server(base string) *serverCapabilities {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s, ok := n.servers[base]
+	if !ok {
+		s = &serverCapabilities{}
+		n.servers[base] = s
+	}
+	return s
+}
+
+func (n *FeatureNegotiator) capabilities(ctx context.Context, rt http.RoundTripper, base string, header http.Header) (*Capabilities, error) {
+	s := n.server(base)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.caps != nil && n.now().Sub(s.fetched) < n.cfg.TTL {
+		return s.caps, nil
+	}
+	caps, err := fetchCapabilities(ctx, rt, base, header)
+	if err != nil {
+		if s.caps != nil {
+			// Keep serving the last known capabilities while the server
+			// is unreachable.
+			return s.caps, nil
+		}
+		return nil, err
+	}
+	s.caps = caps
+	s.fetched = n.now()
+	if !s.warned {
+		if err := CheckAPIVersion(caps.APIVersion); err != nil {
+			s.warned = true
+			n.cfg.OnVersionMismatch(base, err)
+		}
+	}
+	return caps, nil
+}
+
+func fetchCapabilities(ctx context.Context, rt http.RoundTripper, base string, header http.Header) (*Capabilities, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/version", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for _, h := range []string{"Authorization", "User-Agent"} {
+		if v := header.Get(h); v != "" {
+			req.Header.Set(h, v)
+		}
+	}
+	// Per-call credentials from the generated builders are stored under the
+	// non-canonical "authorization" key.
+	if v, ok := header["authorization"]; ok {
+		req.Header["authorization"] = v
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("hindsight: fetching server version: %s", resp.Status)
+	}
+	var v VersionResponse
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("hindsight: decoding server version: %w", err)
+	}
+	return &Capabilities{APIVersion: v.ApiVersion, Features: v.Features}, nil
+}
+
+// serverBaseURL rebuilds the server URL of req from the path segments that
+// precede the matched route.
+func serverBaseURL(req *http.Request, prefix []string) string {
+	base := req.URL.Scheme + "://" + req.URL.Host
+	for _, seg := range prefix {
+		if seg != "" {
+			base += "/" + seg
+		}
+	}
+	return base
+}
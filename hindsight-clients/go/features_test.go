@@ -0,0 +1,105 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFeatureNegotiation(t *testing.T) {
+	var versionCalls, configCalls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/version":
+			atomic.AddInt32(&versionCalls, 1)
+			fmt.Fprint(w, `{"api_version":"0.6.1","features":{"observations":true,"mcp":true,"worker":true,"bank_config_api":false,"file_upload_api":true}}`)
+		case "/api/v1/default/banks/b/config":
+			atomic.AddInt32(&configCalls, 1)
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			fmt.Fprint(w, `{"banks":[]}`)
+		}
+	}))
+	defer srv.Close()
+
+	var warnings []error
+	negotiator := NewFeatureNegotiator(FeatureNegotiatorConfig{
+		OnVersionMismatch: func(serverURL string, err error) { warnings = append(warnings, err) },
+	})
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL + "/api"}}
+	cfg.Use(negotiator.Middleware())
+	client := NewAPIClient(cfg)
+	ctx := context.Background()
+
+	if _, _, err := client.BanksAPI.ListBanks(ctx).Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&versionCalls); n != 0 {
+		t.Fatalf("ungated operation fetched the version %d times", n)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, _, err := client.BanksAPI.UpdateBankConfig(ctx, "b").BankConfigUpdate(BankConfigUpdate{}).Execute()
+		var featErr *FeatureUnavailableError
+		if !errors.Is(err, ErrFeatureUnavailable) || !errors.As(err, &featErr) || featErr.Feature != FeatureBankConfigAPI {
+			t.Fatalf("expected FeatureUnavailableError, got %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&configCalls); n != 0 {
+		t.Fatalf("disabled endpoint was called %d times", n)
+	}
+	if n := atomic.LoadInt32(&versionCalls); n != 1 {
+		t.Fatalf("capabilities fetched %d times, want 1", n)
+	}
+	var mismatch *VersionMismatchError
+	if len(warnings) != 1 || !errors.As(warnings[0], &mismatch) || mismatch.ServerVersion != "0.6.1" {
+		t.Fatalf("expected one version warning, got %v", warnings)
+	}
+
+	caps, err := negotiator.Capabilities(ctx, nil, srv.URL+"/api/")
+	if err != nil || !caps.Has(FeatureFileUploadAPI) || caps.Has(FeatureBankConfigAPI) {
+		t.Fatalf("Capabilities = %+v, %v", caps, err)
+	}
+}
+
+func TestCheckAPIVersion(t *testing.T) {
+	for version, ok := range map[string]bool{
+		"0.4.0":      true,
+		"0.4.14":     true,
+		"v0.4.20-rc": true,
+		"0.3.9":      false,
+		"0.5.0":      false,
+		"1.0":        false,
+	} {
+		if err := CheckAPIVersion(version); (err == nil) != ok {
+			t.Errorf("CheckAPIVersion(%q) = %v", version, err)
+		}
+	}
+	if err := CheckAPIVersion("dev"); err == nil {
+		t.Error("expected an error for an unparseable version")
+	}
+}
+
+func TestFeatureNegotiationPerCallCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer per-call" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"api_version":"0.6.1","features":{"observations":true,"mcp":true,"worker":true,"bank_config_api":true,"file_upload_api":true}}`)
+	}))
+	defer srv.Close()
+
+	header := http.Header{"authorization": {"Bearer per-call"}}
+	caps, err := fetchCapabilities(context.Background(), http.DefaultTransport, srv.URL, header)
+	if err != nil || !caps.Has(FeatureBankConfigAPI) {
+		t.Fatalf("Capabilities = %+v, %v", caps, err)
+	}
+}
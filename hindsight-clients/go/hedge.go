@@ -0,0 +1,314 @@
+package hindsight
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HedgingConfig configures a Hedger. Zero values select the documented
+// defaults.
+type HedgingConfig struct {
+	// Delay is how long to wait for the first response before sending a
+	// hedge. Zero derives the delay from the observed p95 latency of the
+	// same operation; until enough calls have been seen, nothing is hedged.
+	Delay time.Duration
+	// MaxHedgeRate caps hedges as a fraction of requests, so a slow server
+	// does not double the load on the cluster. Each request earns
+	// MaxHedgeRate of a hedge; a new Hedger starts with one, and up to 10
+	// unspent hedges are kept for bursts. Defaults to 0.1.
+	MaxHedgeRate float64
+	// Servers lists the servers a hedge may go to, usually the
+	// Configuration's Servers. A request sent to one of them is hedged to
+	// the next one in the list. When empty, or when the request's server is
+	// not listed, the hedge goes to the same server.
+	Servers ServerConfigurations
+}
+
+// HedgingStats reports a Hedger's activity.
+type HedgingStats struct {
+	Requests int64
+	// Hedged counts requests for which a second request was sent.
+	Hedged int64
+	// HedgeWins counts hedged requests answered by the hedge.
+	HedgeWins int64
+}
+
+const (
+	hedgeWindow     = 100
+	hedgeMinSamples = 20
+	hedgeMaxTokens  = 10
+)
+
+// Hedger sends a second copy of a slow recall or read-only GET request and
+// returns whichever response arrives first, cancelling the other. It cuts
+// the tail latency caused by an occasionally slow replica.
+//
+// Only requests that are safe to send twice are hedged: GETs and recalls
+// whose body can be replayed. A response that fails with a network error or
+// a 5xx status does not win while the other request is still running.
+type Hedger struct {
+	cfg HedgingConfig
+
+	mu        sync.Mutex
+	latencies map[string]*latencyWindow // operation name -> recent latencies
+	tokens    float64
+	stats     HedgingStats
+}
+
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+}
+
+// NewHedger creates a Hedger. Install it with
+// Configuration.Use(h.Middleware()), outside any retry middleware.
+func NewHedger(cfg HedgingConfig) *Hedger {
+	if cfg.MaxHedgeRate <= 0 {
+		cfg.MaxHedgeRate = 0.1
+	}
+	return &Hedger{cfg: cfg, latencies: make(map[string]*latencyWindow), tokens: 1}
+}
+
+// Stats returns the hedger's counters.
+func (h *Hedger) Stats() HedgingStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.stats
+}
+
+// Middleware returns the middleware that hedges requests.
+func (h *Hedger) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			op := OperationForRequest(req)
+			if req.Method != http.MethodGet && op.Class != OperationClassRecall {
+				return next.RoundTrip(req)
+			}
+			if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+				return next.RoundTrip(req)
+			}
+			return h.roundTrip(next, req, op.Name)
+		})
+	}
+}
+
+type hedgeResult struct {
+	resp    *http.Response
+	err     error
+	attempt int
+	elapsed time.Duration
+}
+
+func (r hedgeResult) failed() bool {
+	return r.err != nil || r.resp.StatusCode >= 500
+}
+
+func (r hedgeResult) discard() {
+	if r.resp != nil {
+		r.resp.Body.Close()
+	}
+}
+
+func (h *Hedger) roundTrip(next http.RoundTripper, req *http.Request, name string) (*http.Response, error) {
+	delay, hedging := h.delay(name)
+	if !hedging {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		if err == nil && resp.StatusCode < 500 {
+			h.observe(name, time.Since(start))
+		}
+		return resp, err
+	}
+
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	send := func(out *http.Request, cancel context.CancelFunc) {
+		attempt := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			start := time.Now()
+			resp, err := next.RoundTrip(out)
+			results <- hedgeResult{resp: resp, err: err, attempt: attempt, elapsed: time.Since(start)}
+		}()
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	send(req.Clone(ctx), cancel)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	pending := 1
+	var fallback *hedgeResult
+	for {
+		select {
+		case <-timer.C:
+			if out, cancel, ok := h.hedgeRequest(req); ok {
+				send(out, cancel)
+				pending++
+			}
+		case r := <-results:
+			pending--
+			if r.failed() && pending > 0 {
+				fallback = &r
+				continue
+			}
+			switch {
+			case !r.failed():
+				h.observe(name, r.elapsed)
+				if fallback != nil {
+					fallback.discard()
+				}
+			case fallback != nil:
+				// Every attempt failed; report the first failure.
+				r.discard()
+				r = *fallback
+			}
+			for i, cancel := range cancels {
+				if i != r.attempt {
+					cancel()
+				}
+			}
+			if pending > 0 {
+				// Release the cancelled request's connection.
+				go func() { (<-results).discard() }()
+			}
+			if r.attempt > 0 {
+				h.mu.Lock()
+				h.stats.HedgeWins++
+				h.mu.Unlock()
+			}
+			if r.err != nil {
+				cancels[r.attempt]()
+				return nil, r.err
+			}
+			// The winner's context lives until its body has been read.
+			r.resp.Body = &cancelOnClose{ReadCloser: r.resp.Body, cancel: cancels[r.attempt]}
+			r.resp.Request = req
+			return r.resp, nil
+		}
+	}
+}
+
+// cancelOnClose cancels a request's context once its response body is
+// closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// hedgeRequest builds the second request, sent to the next server when the
+// original request's server is one of cfg.Servers. It reports false when
+// the hedge budget is spent or the body cannot be replayed.
+func (h *Hedger) hedgeRequest(req *http.Request) (*http.Request, context.CancelFunc, bool) {
+	h.mu.Lock()
+	if h.tokens < 1 {
+		h.mu.Unlock()
+		return nil, nil, false
+	}
+	h.tokens--
+	h.stats.Hedged++
+	h.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(req.Context())
+	out := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, false
+		}
+		out.Body = body
+	}
+	if u := h.alternateURL(req.URL); u != nil {
+		out.URL = u
+		out.Host = ""
+	}
+	return out, cancel, true
+}
+
+// alternateURL moves u from the configured server it belongs to onto the
+// next one, or returns nil.
+func (h *Hedger) alternateURL(u *url.URL) *url.URL {
+	servers := h.cfg.Servers
+	if len(servers) < 2 {
+		return nil
+	}
+	target := u.String()
+	for i := range servers {
+		base, err := servers.URL(i, nil)
+		if err != nil {
+			continue
+		}
+		base = strings.TrimSuffix(base, "/")
+		if !strings.HasPrefix(target, base) {
+			continue
+		}
+		rest := target[len(base):]
+		if rest != "" && rest[0] != '/' && rest[0] != '?' {
+			continue
+		}
+		alt, err := servers.URL((i+1)%len(servers), nil)
+		if err != nil {
+			return nil
+		}
+		out, err := url.Parse(strings.TrimSuffix(alt, "/") + rest)
+		if err != nil {
+			return nil
+		}
+		return out
+	}
+	return nil
+}
+
+// delay counts a request and returns how long to wait before hedging it. It
+// reports false when there is no fixed delay and too few calls of the
+// operation have been seen to estimate its p95 latency.
+func (h *Hedger) delay(name string) (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stats.Requests++
+	h.tokens += h.cfg.MaxHedgeRate
+	if h.tokens > hedgeMaxTokens {
+		h.tokens = hedgeMaxTokens
+	}
+	if h.cfg.Delay > 0 {
+		return h.cfg.Delay, true
+	}
+	w := h.latencies[name]
+	if w == nil || len(w.samples) < hedgeMinSamples {
+		return 0, false
+	}
+	sorted := make([]time.Duration, len(w.samples))
+	copy(sorted, w.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)*95/100], true
+}
+
+// observe records the latency of a successful call, keeping the most recent
+// hedgeWindow calls per operation.
+func (h *Hedger) observe(name string, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	w := h.latencies[name]
+	if w == nil {
+		w = &latencyWindow{}
+		h.latencies[name] = w
+	}
+	if len(w.samples) < hedgeWindow {
+		w.samples = append(w.samples, d)
+		return
+	}
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % hedgeWindow
+}
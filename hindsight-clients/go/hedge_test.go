@@ -0,0 +1,86 @@
+package hindsight
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHedger(t *testing.T) {
+	var slowCancelled int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+		atomic.AddInt32(&slowCancelled, 1)
+	}))
+	defer slow.Close()
+	var fastCalls int32
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if b, _ := io.ReadAll(r.Body); len(b) == 0 {
+			t.Error("hedge was sent without the recall body")
+		}
+		atomic.AddInt32(&fastCalls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"results":[{"id":"m1","text":"Alice prefers tea"}]}`)
+	}))
+	defer fast.Close()
+
+	hedger := NewHedger(HedgingConfig{
+		Delay:        20 * time.Millisecond,
+		MaxHedgeRate: 0.1,
+		Servers:      ServerConfigurations{{URL: slow.URL}, {URL: fast.URL}},
+	})
+	client, err := New(WithBaseURL(slow.URL), WithHedging(hedger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// The slow server's request is hedged to the next server, whose answer
+	// wins, and the slow request is cancelled.
+	resp, err := client.Bank("agent").Recall(ctx, "tea")
+	if err != nil || resp.Results[0].Text != "Alice prefers tea" {
+		t.Fatalf("Recall = %+v, %v", resp, err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&slowCancelled) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&slowCancelled) != 1 {
+		t.Fatal("losing request was not cancelled")
+	}
+	if s := hedger.Stats(); s.Requests != 1 || s.Hedged != 1 || s.HedgeWins != 1 {
+		t.Fatalf("stats = %+v", s)
+	}
+
+	// The first hedge spent the budget, so the next recall waits on the slow
+	// server until its deadline.
+	ctx2, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.Bank("agent").Recall(ctx2, "tea"); err == nil {
+		t.Fatal("unhedged recall to the slow server succeeded")
+	}
+	if n := atomic.LoadInt32(&fastCalls); n != 1 {
+		t.Fatalf("fast server saw %d calls, want 1", n)
+	}
+}
+
+func TestHedgerDerivedDelay(t *testing.T) {
+	h := NewHedger(HedgingConfig{})
+	if _, ok := h.delay("recall"); ok {
+		t.Fatal("hedging without latency samples")
+	}
+	for i := 1; i <= 100; i++ {
+		h.observe("recall", time.Duration(i)*time.Millisecond)
+	}
+	if d, ok := h.delay("recall"); !ok || d != 96*time.Millisecond {
+		t.Fatalf("delay = %v, %v, want the p95 latency", d, ok)
+	}
+	if _, ok := h.delay("list_documents"); ok {
+		t.Fatal("latencies were shared across operations")
+	}
+}
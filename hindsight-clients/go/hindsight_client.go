@@ -7,6 +7,7 @@ import (
 
 // NewAPIClientWithToken creates a new API client configured with a base URL and API token.
 // The token is sent as a Bearer token in the Authorization header for all requests.
+// The base URL may be a Unix domain socket such as "unix:///run/hindsight/api.sock".
 // Note: this uses http.DefaultClient which has no timeout. Use NewAPIClientWithTimeout
 // to set a request timeout.
 //
@@ -20,11 +21,16 @@ func NewAPIClientWithToken(baseURL, token string) *APIClient {
 		{URL: baseURL},
 	}
 	cfg.AddDefaultHeader("Authorization", "Bearer "+token)
+	if isUnixSocketURL(baseURL) {
+		cfg.Use(UnixSocketMiddleware())
+	}
+	cfg.Use(DeadlineHeaderMiddleware())
 	return NewAPIClient(cfg)
 }
 
 // NewAPIClientWithTimeout creates a new API client configured with a base URL, API token,
-// and a request timeout. Use 0 for no timeout.
+// and a request timeout. Use 0 for no timeout. The timeout applies to every operation
+// alike; TimeoutMiddleware sets one per operation instead.
 //
 // Example:
 //
@@ -37,5 +43,30 @@ func NewAPIClientWithTimeout(baseURL, token string, timeout time.Duration) *APIC
 	}
 	cfg.AddDefaultHeader("Authorization", "Bearer "+token)
 	cfg.HTTPClient = &http.Client{Timeout: timeout}
+	if isUnixSocketURL(baseURL) {
+		cfg.Use(UnixSocketMiddleware())
+	}
+	cfg.Use(DeadlineHeaderMiddleware())
+	return NewAPIClient(cfg)
+}
+
+// NewAPIClientWithTokenSource creates a new API client configured with a base URL and a
+// TokenSource that is consulted on every request, so rotated credentials are picked up
+// without rebuilding the client.
+//
+// Example:
+//
+//	ts := hindsight.FileTokenSource("/var/run/secrets/hindsight/api-key", 0)
+//	client := hindsight.NewAPIClientWithTokenSource("https://api.example.com", ts)
+func NewAPIClientWithTokenSource(baseURL string, ts TokenSource) *APIClient {
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{
+		{URL: baseURL},
+	}
+	cfg.SetTokenSource(ts)
+	if isUnixSocketURL(baseURL) {
+		cfg.Use(UnixSocketMiddleware())
+	}
+	cfg.Use(DeadlineHeaderMiddleware())
 	return NewAPIClient(cfg)
 }
@@ -234,7 +234,7 @@ func TestRecallFullFeatured(t *testing.T) {
 
 	req := RecallRequest{
 		Query:     "What are people's hobbies?",
-		Types:     []string{"world"},
+		Types:     []FactType{FactTypeWorld},
 		MaxTokens: PtrInt32(2048),
 		Trace:     PtrBool(true),
 	}
@@ -0,0 +1,262 @@
+// Package launcher starts a local Hindsight API process for integration tests
+// and developer tools, waits until it is ready, and tears it down again.
+//
+// Example:
+//
+//	inst, err := launcher.Start(ctx, launcher.Config{
+//		Command: []string{"uv", "run", "hindsight-api"},
+//		Dir:     "../../hindsight-api",
+//		Logs:    os.Stderr,
+//	})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer inst.Stop(context.Background())
+//	inst.Client.MemoryAPI.RetainMemories(ctx, bankID).RetainRequest(req).Execute()
+package launcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	hindsight "github.com/vectorize-io/hindsight/hindsight-clients/go"
+)
+
+// Config describes how to start the Hindsight API process.
+type Config struct {
+	// Command is the program and arguments to run. Defaults to
+	// []string{"hindsight-api"}. The host and port are passed through the
+	// HINDSIGHT_API_HOST and HINDSIGHT_API_PORT environment variables, which
+	// both hindsight-api and hindsight-embed honour.
+	Command []string
+	// Dir is the working directory of the process. Defaults to the current
+	// directory.
+	Dir string
+	// Env holds extra KEY=VALUE environment entries, appended to the current
+	// process environment.
+	Env []string
+	// Host is the interface to bind. Defaults to 127.0.0.1.
+	Host string
+	// Port is the port to bind. Defaults to 8888; use -1 to pick a free port.
+	Port int
+	// Logs receives the combined stdout and stderr of the process. Nil
+	// discards them.
+	Logs io.Writer
+	// StartTimeout bounds how long Start waits for the server to become
+	// ready. Defaults to 2 minutes, which allows for first-run model
+	// downloads.
+	StartTimeout time.Duration
+	// StopTimeout bounds how long Stop waits after the stop signal before
+	// killing the process. Defaults to 10 seconds.
+	StopTimeout time.Duration
+	// StopSignal is sent to request a graceful shutdown. Defaults to
+	// os.Interrupt; platforms that cannot deliver it fall back to a kill.
+	StopSignal os.Signal
+	// PollInterval is the delay between readiness probes. Defaults to 250ms.
+	PollInterval time.Duration
+	// NoReuse starts a new process even when a healthy server already
+	// answers on Host and Port.
+	NoReuse bool
+}
+
+// Instance is a running (or reused) Hindsight API server.
+type Instance struct {
+	// URL is the base URL of the server.
+	URL string
+	// Client is a ready API client for URL.
+	Client *hindsight.APIClient
+	// Version is the server's GetVersion response.
+	Version *hindsight.VersionResponse
+
+	cfg    Config
+	cmd    *exec.Cmd
+	exited chan struct{}
+	err    error
+	once   sync.Once
+}
+
+// Reused reports whether Start found an already-running server instead of
+// starting a new process.
+func (i *Instance) Reused() bool {
+	return i.cmd == nil
+}
+
+// Start launches the configured process, or reuses a healthy server already
+// listening on the configured address, and waits until both the health and
+// version endpoints succeed.
+func Start(ctx context.Context, cfg Config) (*Instance, error) {
+	if len(cfg.Command) == 0 {
+		cfg.Command = []string{"hindsight-api"}
+	}
+	if cfg.Host == "" {
+		cfg.Host = "127.0.0.1"
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 8888
+	}
+	if cfg.StartTimeout <= 0 {
+		cfg.StartTimeout = 2 * time.Minute
+	}
+	if cfg.StopTimeout <= 0 {
+		cfg.StopTimeout = 10 * time.Second
+	}
+	if cfg.StopSignal == nil {
+		cfg.StopSignal = os.Interrupt
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 250 * time.Millisecond
+	}
+
+	if cfg.Port < 0 {
+		port, err := freePort(cfg.Host)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Port = port
+	} else if !cfg.NoReuse {
+		inst := newInstance(cfg)
+		probeCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		err := inst.probe(probeCtx)
+		cancel()
+		if err == nil {
+			return inst, nil
+		}
+	}
+
+	inst := newInstance(cfg)
+	cmd := exec.Command(cfg.Command[0], cfg.Command[1:]...)
+	cmd.Dir = cfg.Dir
+	cmd.Env = append(os.Environ(), cfg.Env...)
+	cmd.Env = append(cmd.Env,
+		"HINDSIGHT_API_HOST="+cfg.Host,
+		"HINDSIGHT_API_PORT="+strconv.Itoa(cfg.Port),
+	)
+	logs := cfg.Logs
+	if logs == nil {
+		logs = io.Discard
+	}
+	cmd.Stdout = logs
+	cmd.Stderr = logs
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("launcher: starting %s: %w", cfg.Command[0], err)
+	}
+	inst.cmd = cmd
+	inst.exited = make(chan struct{})
+	go func() {
+		inst.err = cmd.Wait()
+		close(inst.exited)
+	}()
+
+	if err := inst.waitReady(ctx); err != nil {
+		inst.Stop(context.Background())
+		return nil, err
+	}
+	return inst, nil
+}
+
+func newInstance(cfg Config) *Instance {
+	url := "http://" + net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	hcfg := hindsight.NewConfiguration()
+	hcfg.Servers = hindsight.ServerConfigurations{
+		{URL: url, Description: "local process"},
+	}
+	return &Instance{URL: url, Client: hindsight.NewAPIClient(hcfg), cfg: cfg}
+}
+
+// probe checks the health endpoint and then fetches the version.
+func (i *Instance) probe(ctx context.Context) error {
+	if _, _, err := i.Client.MonitoringAPI.HealthEndpointHealthGet(ctx).Execute(); err != nil {
+		return err
+	}
+	version, _, err := i.Client.MonitoringAPI.GetVersion(ctx).Execute()
+	if err != nil {
+		return err
+	}
+	i.Version = version
+	return nil
+}
+
+func (i *Instance) waitReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, i.cfg.StartTimeout)
+	defer cancel()
+	ticker := time.NewTicker(i.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		probeCtx, probeCancel := context.WithTimeout(ctx, 5*time.Second)
+		lastErr = i.probe(probeCtx)
+		probeCancel()
+		if lastErr == nil {
+			return nil
+		}
+		select {
+		case <-i.exited:
+			return fmt.Errorf("launcher: %s exited before becoming ready: %v", i.cfg.Command[0], i.err)
+		case <-ctx.Done():
+			return fmt.Errorf("launcher: server at %s not ready: %w (last error: %v)", i.URL, ctx.Err(), lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop asks the process to shut down with the configured signal and waits up
+// to StopTimeout, or until ctx ends, before killing it. Stopping a reused
+// instance is a no-op, since this package did not start it.
+func (i *Instance) Stop(ctx context.Context) error {
+	if i.cmd == nil {
+		return nil
+	}
+	var err error
+	i.once.Do(func() {
+		select {
+		case <-i.exited:
+			return
+		default:
+		}
+		if sigErr := i.cmd.Process.Signal(i.cfg.StopSignal); sigErr != nil {
+			i.cmd.Process.Kill()
+		}
+		timer := time.NewTimer(i.cfg.StopTimeout)
+		defer timer.Stop()
+		select {
+		case <-i.exited:
+		case <-timer.C:
+			i.cmd.Process.Kill()
+			<-i.exited
+			err = errors.New("launcher: process did not stop gracefully and was killed")
+		case <-ctx.Done():
+			i.cmd.Process.Kill()
+			<-i.exited
+			err = ctx.Err()
+		}
+	})
+	return err
+}
+
+// Wait blocks until the started process exits and returns its exit error.
+// It returns nil immediately for reused instances.
+func (i *Instance) Wait() error {
+	if i.cmd == nil {
+		return nil
+	}
+	<-i.exited
+	return i.err
+}
+
+func freePort(host string) (int, error) {
+	ln, err := net.Listen("tcp", net.JoinHostPort(host, "0"))
+	if err != nil {
+		return 0, fmt.Errorf("launcher: finding a free port: %w", err)
+	}
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,122 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"testing"
+	"time"
+)
+
+// TestHelperProcess is not a real test. It is re-executed by the tests below
+// as a stand-in for hindsight-api.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	addr := net.JoinHostPort(os.Getenv("HINDSIGHT_API_HOST"), os.Getenv("HINDSIGHT_API_PORT"))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"healthy"}`)
+	})
+	mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"api_version":"0.4.14","features":{"observations":true,"mcp":false,"worker":true,"bank_config_api":true,"file_upload_api":false}}`)
+	})
+	srv := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt)
+		<-sig
+		fmt.Println("helper: shutting down")
+		srv.Close()
+	}()
+	fmt.Println("helper: listening on", addr)
+	srv.ListenAndServe()
+	os.Exit(0)
+}
+
+func helperConfig(t *testing.T) Config {
+	return Config{
+		Command:      []string{os.Args[0], "-test.run=TestHelperProcess"},
+		Env:          []string{"GO_WANT_HELPER_PROCESS=1"},
+		Port:         -1,
+		StartTimeout: 10 * time.Second,
+		PollInterval: 20 * time.Millisecond,
+	}
+}
+
+type logBuffer struct {
+	lines chan string
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	select {
+	case b.lines <- string(p):
+	default:
+	}
+	return len(p), nil
+}
+
+func TestStartAndStop(t *testing.T) {
+	cfg := helperConfig(t)
+	logs := &logBuffer{lines: make(chan string, 16)}
+	cfg.Logs = logs
+
+	inst, err := Start(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inst.Reused() {
+		t.Fatal("expected a new process")
+	}
+	if inst.Version.ApiVersion != "0.4.14" {
+		t.Fatalf("version = %q", inst.Version.ApiVersion)
+	}
+	if _, _, err := inst.Client.MonitoringAPI.HealthEndpointHealthGet(context.Background()).Execute(); err != nil {
+		t.Fatalf("client not ready: %v", err)
+	}
+	select {
+	case <-logs.lines:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no logs streamed from the child process")
+	}
+
+	// A second Start on the same address reuses the running server.
+	reuseCfg := cfg
+	reuseCfg.Port = portOf(t, inst.URL)
+	reused, err := Start(context.Background(), reuseCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reused.Reused() {
+		t.Fatal("expected the running instance to be reused")
+	}
+	if err := reused.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := inst.Stop(context.Background()); err != nil {
+		t.Fatalf("graceful stop failed: %v", err)
+	}
+}
+
+func TestStartFailsWhenProcessExits(t *testing.T) {
+	cfg := helperConfig(t)
+	cfg.Env = nil // the helper exits immediately without GO_WANT_HELPER_PROCESS
+	if _, err := Start(context.Background(), cfg); err == nil {
+		t.Fatal("expected an error when the process exits before becoming ready")
+	}
+}
+
+func portOf(t *testing.T, rawURL string) int {
+	t.Helper()
+	var port int
+	_, p, _ := net.SplitHostPort(rawURL[len("http://"):])
+	fmt.Sscan(p, &port)
+	return port
+}
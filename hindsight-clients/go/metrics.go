@@ -0,0 +1,482 @@
+package hindsight
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetricType is the type declared for a metric family in the Prometheus text
+// exposition format.
+type MetricType string
+
+const (
+	MetricTypeCounter   MetricType = "counter"
+	MetricTypeGauge     MetricType = "gauge"
+	MetricTypeHistogram MetricType = "histogram"
+	MetricTypeSummary   MetricType = "summary"
+	MetricTypeUntyped   MetricType = "untyped"
+)
+
+// Label names the Hindsight server attaches to its operation and HTTP metrics.
+const (
+	MetricLabelBankID    = "bank_id"
+	MetricLabelOperation = "operation"
+	MetricLabelTenant    = "tenant"
+)
+
+// MetricOperationDuration is the family name of the server's per-operation
+// latency histogram.
+const MetricOperationDuration = "hindsight_operation_duration_seconds"
+
+// Metrics is a scrape of the server's /metrics endpoint.
+type Metrics struct {
+	// Raw is the exposition text as served.
+	Raw string
+	// Families holds the parsed metric families in the order they appeared.
+	Families []*MetricFamily
+}
+
+// MetricFamily is a group of samples sharing a metric name and type.
+type MetricFamily struct {
+	Name string
+	Help string
+	Type MetricType
+	Unit string
+	// Samples holds every sample of the family, including the _bucket, _sum
+	// and _count series of histograms and summaries.
+	Samples []Sample
+	// Histograms groups the samples of a histogram family by label set.
+	// It is empty for other types.
+	Histograms []Histogram
+}
+
+// Sample is a single time series value.
+type Sample struct {
+	// Name is the series name, which for histograms and summaries carries a
+	// suffix such as _bucket or _count.
+	Name   string
+	Labels map[string]string
+	Value  float64
+	// Timestamp is the optional sample timestamp in milliseconds since the
+	// epoch; zero when absent.
+	Timestamp int64
+}
+
+// Histogram is one labelled series of a histogram family.
+type Histogram struct {
+	// Labels excludes the "le" bucket label.
+	Labels map[string]string
+	// Buckets are sorted by upper bound and hold cumulative counts.
+	Buckets []Bucket
+	Sum     float64
+	Count   float64
+}
+
+// Bucket is a cumulative histogram bucket.
+type Bucket struct {
+	UpperBound float64
+	Count      float64
+}
+
+// Quantile estimates the q-quantile (0 <= q <= 1) by linear interpolation
+// within buckets, as Prometheus' histogram_quantile does. It returns NaN for
+// an empty histogram.
+func (h Histogram) Quantile(q float64) float64 {
+	if len(h.Buckets) == 0 {
+		return math.NaN()
+	}
+	total := h.Buckets[len(h.Buckets)-1].Count
+	if total == 0 {
+		return math.NaN()
+	}
+	rank := q * total
+	prevBound, prevCount := 0.0, 0.0
+	for _, b := range h.Buckets {
+		if b.Count >= rank {
+			if math.IsInf(b.UpperBound, 1) {
+				return prevBound
+			}
+			if b.Count == prevCount {
+				return b.UpperBound
+			}
+			return prevBound + (b.UpperBound-prevBound)*(rank-prevCount)/(b.Count-prevCount)
+		}
+		prevBound, prevCount = b.UpperBound, b.Count
+	}
+	return prevBound
+}
+
+// Family returns the family with the given name, or nil.
+func (m *Metrics) Family(name string) *MetricFamily {
+	for _, f := range m.Families {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// Select returns the samples of all families whose labels include every
+// name/value pair in match.
+func (m *Metrics) Select(match map[string]string) []Sample {
+	var out []Sample
+	for _, f := range m.Families {
+		out = append(out, f.Select(match)...)
+	}
+	return out
+}
+
+// ForBank returns all samples labelled with the given bank.
+func (m *Metrics) ForBank(bankID string) []Sample {
+	return m.Select(map[string]string{MetricLabelBankID: bankID})
+}
+
+// ForOperation returns all samples labelled with the given server operation,
+// for example "recall" or "retain".
+func (m *Metrics) ForOperation(operation string) []Sample {
+	return m.Select(map[string]string{MetricLabelOperation: operation})
+}
+
+// OperationDurations returns the server's latency histograms for operation
+// and bankID. Empty arguments match any value.
+func (m *Metrics) OperationDurations(operation, bankID string) []Histogram {
+	f := m.Family(MetricOperationDuration)
+	if f == nil {
+		return nil
+	}
+	match := map[string]string{}
+	if operation != "" {
+		match[MetricLabelOperation] = operation
+	}
+	if bankID != "" {
+		match[MetricLabelBankID] = bankID
+	}
+	var out []Histogram
+	for _, h := range f.Histograms {
+		if labelsMatch(h.Labels, match) {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// Select returns the family's samples whose labels include every name/value
+// pair in match.
+func (f *MetricFamily) Select(match map[string]string) []Sample {
+	var out []Sample
+	for _, s := range f.Samples {
+		if labelsMatch(s.Labels, match) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func labelsMatch(labels, match map[string]string) bool {
+	for k, v := range match {
+		if got, ok := labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ExecuteMetrics performs the request like Execute, but asks for and parses
+// the Prometheus text format the endpoint actually serves.
+func (r ApiMetricsEndpointMetricsGetRequest) ExecuteMetrics() (*Metrics, *http.Response, error) {
+	a := r.ApiService
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "MonitoringAPIService.MetricsEndpointMetricsGet")
+	if err != nil {
+		return nil, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+	headers := map[string]string{"Accept": "text/plain"}
+	req, err := a.client.prepareRequest(r.ctx, localBasePath+"/metrics", http.MethodGet, nil, headers, url.Values{}, url.Values{}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := a.client.callAPI(req)
+	if err != nil || resp == nil {
+		return nil, resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewBuffer(body))
+	if err != nil {
+		return nil, resp, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, resp, &GenericOpenAPIError{body: body, error: resp.Status, requestID: ResponseRequestID(resp)}
+	}
+	metrics, err := ParseMetrics(bytes.NewReader(body))
+	if err != nil {
+		return nil, resp, &GenericOpenAPIError{body: body, error: err.Error(), requestID: ResponseRequestID(resp)}
+	}
+	return metrics, resp, nil
+}
+
+// ParseMetrics parses the Prometheus text exposition format.
+func ParseMetrics(r io.Reader) (*Metrics, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := metricsParser{byName: make(map[string]*MetricFamily)}
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if err := p.line(scanner.Text()); err != nil {
+			return nil, fmt.Errorf("hindsight: metrics line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for _, f := range p.families {
+		if f.Type == MetricTypeHistogram {
+			f.Histograms = buildHistograms(f)
+		}
+	}
+	return &Metrics{Raw: string(raw), Families: p.families}, nil
+}
+
+type metricsParser struct {
+	families []*MetricFamily
+	byName   map[string]*MetricFamily
+}
+
+func (p *metricsParser) family(name string) *MetricFamily {
+	f, ok := p.byName[name]
+	if !ok {
+		f = &MetricFamily{Name: name, Type: MetricTypeUntyped}
+		p.byName[name] = f
+		p.families = append(p.families, f)
+	}
+	return f
+}
+
+func (p *metricsParser) line(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+	if strings.HasPrefix(line, "#") {
+		fields := strings.SplitN(strings.TrimSpace(line[1:]), " ", 3)
+		if len(fields) < 2 {
+			return nil
+		}
+		rest := ""
+		if len(fields) == 3 {
+			rest = strings.TrimSpace(fields[2])
+		}
+		switch fields[0] {
+		case "HELP":
+			p.family(fields[1]).Help = unescapeMetricText(rest)
+		case "TYPE":
+			p.family(fields[1]).Type = MetricType(strings.ToLower(rest))
+		case "UNIT":
+			p.family(fields[1]).Unit = rest
+		}
+		return nil
+	}
+
+	s, err := parseSample(line)
+	if err != nil {
+		return err
+	}
+	f := p.familyForSample(s.Name)
+	f.Samples = append(f.Samples, s)
+	return nil
+}
+
+// familyForSample finds the declared family a series belongs to, stripping
+// the suffixes histograms, summaries and counters add to their series.
+func (p *metricsParser) familyForSample(name string) *MetricFamily {
+	if f, ok := p.byName[name]; ok {
+		return f
+	}
+	for _, suffix := range []string{"_bucket", "_sum", "_count", "_created", "_total"} {
+		base := strings.TrimSuffix(name, suffix)
+		if base == name {
+			continue
+		}
+		if f, ok := p.byName[base]; ok {
+			switch f.Type {
+			case MetricTypeHistogram, MetricTypeSummary:
+				return f
+			case MetricTypeCounter:
+				if suffix == "_total" || suffix == "_created" {
+					return f
+				}
+			}
+		}
+	}
+	return p.family(name)
+}
+
+func parseSample(line string) (Sample, error) {
+	s := Sample{Labels: map[string]string{}}
+	i := strings.IndexAny(line, "{ \t")
+	if i < 0 {
+		return s, fmt.Errorf("missing value in %q", line)
+	}
+	s.Name = line[:i]
+	rest := line[i:]
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		rest, err = parseLabels(rest[1:], s.Labels)
+		if err != nil {
+			return s, err
+		}
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return s, fmt.Errorf("malformed sample %q", line)
+	}
+	v, err := parseMetricValue(fields[0])
+	if err != nil {
+		return s, err
+	}
+	s.Value = v
+	if len(fields) == 2 {
+		ts, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return s, fmt.Errorf("invalid timestamp %q", fields[1])
+		}
+		s.Timestamp = ts
+	}
+	return s, nil
+}
+
+// parseLabels reads `name="value",...}` into labels and returns the text
+// after the closing brace.
+func parseLabels(s string, labels map[string]string) (string, error) {
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if strings.HasPrefix(s, "}") {
+			return s[1:], nil
+		}
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 {
+			return "", fmt.Errorf("malformed label set near %q", s)
+		}
+		name := strings.TrimSpace(s[:eq])
+		s = strings.TrimLeft(s[eq+1:], " \t")
+		if !strings.HasPrefix(s, `"`) {
+			return "", fmt.Errorf("unquoted value for label %q", name)
+		}
+		var value strings.Builder
+		closed := false
+		i := 1
+		for ; i < len(s); i++ {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			if c == '"' {
+				closed = true
+				break
+			}
+			value.WriteByte(c)
+		}
+		if !closed {
+			return "", fmt.Errorf("unterminated value for label %q", name)
+		}
+		labels[name] = value.String()
+		s = s[i+1:]
+	}
+}
+
+func parseMetricValue(s string) (float64, error) {
+	switch s {
+	case "+Inf", "Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+func unescapeMetricText(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(s)
+}
+
+func buildHistograms(f *MetricFamily) []Histogram {
+	var out []Histogram
+	index := make(map[string]int)
+	get := func(labels map[string]string) *Histogram {
+		key := labelKey(labels, "le")
+		i, ok := index[key]
+		if !ok {
+			series := make(map[string]string, len(labels))
+			for k, v := range labels {
+				if k != "le" {
+					series[k] = v
+				}
+			}
+			out = append(out, Histogram{Labels: series})
+			i = len(out) - 1
+			index[key] = i
+		}
+		return &out[i]
+	}
+	for _, s := range f.Samples {
+		switch s.Name {
+		case f.Name + "_bucket":
+			bound, err := parseMetricValue(s.Labels["le"])
+			if err != nil {
+				continue
+			}
+			h := get(s.Labels)
+			h.Buckets = append(h.Buckets, Bucket{UpperBound: bound, Count: s.Value})
+		case f.Name + "_sum":
+			get(s.Labels).Sum = s.Value
+		case f.Name + "_count":
+			get(s.Labels).Count = s.Value
+		}
+	}
+	for i := range out {
+		buckets := out[i].Buckets
+		sort.Slice(buckets, func(a, b int) bool { return buckets[a].UpperBound < buckets[b].UpperBound })
+	}
+	return out
+}
+
+// labelKey renders labels, minus skip, in a canonical order.
+func labelKey(labels map[string]string, skip string) string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		if k != skip {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, k := range names {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[k]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package hindsight
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testExposition = `# HELP hindsight_operation_duration_seconds Duration of Hindsight operations in seconds
+# TYPE hindsight_operation_duration_seconds histogram
+hindsight_operation_duration_seconds_bucket{bank_id="b1",operation="recall",le="0.1"} 2
+hindsight_operation_duration_seconds_bucket{bank_id="b1",operation="recall",le="1"} 8
+hindsight_operation_duration_seconds_bucket{bank_id="b1",operation="recall",le="+Inf"} 10
+hindsight_operation_duration_seconds_sum{bank_id="b1",operation="recall"} 4.5
+hindsight_operation_duration_seconds_count{bank_id="b1",operation="recall"} 10
+hindsight_operation_duration_seconds_bucket{bank_id="b2",operation="retain",le="0.1"} 0
+hindsight_operation_duration_seconds_bucket{bank_id="b2",operation="retain",le="1"} 1
+hindsight_operation_duration_seconds_bucket{bank_id="b2",operation="retain",le="+Inf"} 1
+hindsight_operation_duration_seconds_sum{bank_id="b2",operation="retain"} 0.7
+hindsight_operation_duration_seconds_count{bank_id="b2",operation="retain"} 1
+# HELP hindsight_operation_operations_total Total number of operations executed
+# TYPE hindsight_operation_operations_total counter
+hindsight_operation_operations_total{bank_id="b1",operation="recall",source="api",success="true"} 10 1700000000000
+# HELP hindsight_http_requests_in_progress Number of HTTP requests in progress \\ "quoted"
+# TYPE hindsight_http_requests_in_progress gauge
+hindsight_http_requests_in_progress{endpoint="/v1/default/banks/{bank_id}",note="a \"b\"\nc"} 3
+orphan_metric NaN
+`
+
+func TestParseMetrics(t *testing.T) {
+	m, err := ParseMetrics(strings.NewReader(testExposition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Families) != 4 {
+		t.Fatalf("got %d families", len(m.Families))
+	}
+
+	hist := m.OperationDurations("recall", "")
+	if len(hist) != 1 {
+		t.Fatalf("got %d recall histograms", len(hist))
+	}
+	h := hist[0]
+	if h.Labels["bank_id"] != "b1" || h.Count != 10 || h.Sum != 4.5 || len(h.Buckets) != 3 || !math.IsInf(h.Buckets[2].UpperBound, 1) {
+		t.Fatalf("unexpected histogram %+v", h)
+	}
+	if q := h.Quantile(0.5); math.Abs(q-0.55) > 1e-9 {
+		t.Fatalf("p50 = %v, want 0.55", q)
+	}
+
+	counter := m.Family("hindsight_operation_operations_total")
+	if counter.Type != MetricTypeCounter || counter.Samples[0].Timestamp != 1700000000000 {
+		t.Fatalf("unexpected counter %+v", counter)
+	}
+	if got := len(m.ForBank("b1")); got != 6 {
+		t.Fatalf("ForBank(b1) returned %d samples, want 6", got)
+	}
+	if got := len(m.ForOperation("retain")); got != 5 {
+		t.Fatalf("ForOperation(retain) returned %d samples, want 5", got)
+	}
+
+	gauge := m.Family("hindsight_http_requests_in_progress")
+	if gauge.Help != `Number of HTTP requests in progress \ "quoted"` {
+		t.Fatalf("help = %q", gauge.Help)
+	}
+	if note := gauge.Samples[0].Labels["note"]; note != "a \"b\"\nc" {
+		t.Fatalf("label = %q", note)
+	}
+	if f := m.Family("orphan_metric"); f == nil || f.Type != MetricTypeUntyped || !math.IsNaN(f.Samples[0].Value) {
+		t.Fatalf("unexpected untyped family %+v", f)
+	}
+
+	if _, err := ParseMetrics(strings.NewReader(`broken{label="x} 1`)); err == nil {
+		t.Fatal("expected a parse error")
+	}
+}
+
+func TestExecuteMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write([]byte(testExposition))
+	}))
+	defer srv.Close()
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	m, _, err := NewAPIClient(cfg).MonitoringAPI.MetricsEndpointMetricsGet(context.Background()).ExecuteMetrics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Raw != testExposition || len(m.OperationDurations("", "b2")) != 1 {
+		t.Fatalf("unexpected metrics %+v", m.Families)
+	}
+}
@@ -0,0 +1,54 @@
+package hindsight
+
+import (
+	"net/http"
+)
+
+// Middleware wraps an http.RoundTripper with additional client-side behaviour
+// such as circuit breaking or rate limiting.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to the http.RoundTripper interface.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Use installs middleware on the configuration's HTTP client. Middleware
+// passed in one call runs in argument order, and each call to Use wraps the
+// middleware installed by earlier calls, so the last Use runs first.
+//
+// The configured HTTPClient is copied rather than modified, so passing
+// http.DefaultClient (or a client shared with other code) is safe. Call Use
+// before NewAPIClient.
+//
+// Example:
+//
+//	cfg := hindsight.NewConfiguration()
+//	cfg.Use(hindsight.NewCircuitBreaker(hindsight.CircuitBreakerConfig{}).Middleware())
+//	client := hindsight.NewAPIClient(cfg)
+func (c *Configuration) Use(middleware ...Middleware) {
+	var httpClient http.Client
+	if c.HTTPClient != nil {
+		httpClient = *c.HTTPClient
+	}
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	httpClient.Transport = transport
+	c.HTTPClient = &httpClient
+}
+
+// closeRequestBody releases the request body when a middleware rejects a
+// request without forwarding it, as required by the http.RoundTripper contract.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the AddBackgroundRequest type satisfies the MappedNullable interface at compile time
@@ -108,11 +107,7 @@ func (o *AddBackgroundRequest) SetUpdateDisposition(v bool) {
 }
 
 func (o AddBackgroundRequest) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o AddBackgroundRequest) ToMap() (map[string]interface{}, error) {
@@ -126,24 +121,14 @@ func (o AddBackgroundRequest) ToMap() (map[string]interface{}, error) {
 
 func (o *AddBackgroundRequest) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"content",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varAddBackgroundRequest := _AddBackgroundRequest{}
@@ -161,40 +146,10 @@ func (o *AddBackgroundRequest) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableAddBackgroundRequest struct {
-	value *AddBackgroundRequest
-	isSet bool
-}
-
-func (v NullableAddBackgroundRequest) Get() *AddBackgroundRequest {
-	return v.value
-}
-
-func (v *NullableAddBackgroundRequest) Set(val *AddBackgroundRequest) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableAddBackgroundRequest) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableAddBackgroundRequest) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableAddBackgroundRequest = Nullable[AddBackgroundRequest]
 
 func NewNullableAddBackgroundRequest(val *AddBackgroundRequest) *NullableAddBackgroundRequest {
-	return &NullableAddBackgroundRequest{value: val, isSet: true}
-}
-
-func (v NullableAddBackgroundRequest) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableAddBackgroundRequest) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
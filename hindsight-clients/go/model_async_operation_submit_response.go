@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the AsyncOperationSubmitResponse type satisfies the MappedNullable interface at compile time
@@ -95,11 +94,7 @@ func (o *AsyncOperationSubmitResponse) SetStatus(v string) {
 }
 
 func (o AsyncOperationSubmitResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o AsyncOperationSubmitResponse) ToMap() (map[string]interface{}, error) {
@@ -111,25 +106,15 @@ func (o AsyncOperationSubmitResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *AsyncOperationSubmitResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"operation_id",
 		"status",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varAsyncOperationSubmitResponse := _AsyncOperationSubmitResponse{}
@@ -147,40 +132,10 @@ func (o *AsyncOperationSubmitResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableAsyncOperationSubmitResponse struct {
-	value *AsyncOperationSubmitResponse
-	isSet bool
-}
-
-func (v NullableAsyncOperationSubmitResponse) Get() *AsyncOperationSubmitResponse {
-	return v.value
-}
-
-func (v *NullableAsyncOperationSubmitResponse) Set(val *AsyncOperationSubmitResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableAsyncOperationSubmitResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableAsyncOperationSubmitResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableAsyncOperationSubmitResponse = Nullable[AsyncOperationSubmitResponse]
 
 func NewNullableAsyncOperationSubmitResponse(val *AsyncOperationSubmitResponse) *NullableAsyncOperationSubmitResponse {
-	return &NullableAsyncOperationSubmitResponse{value: val, isSet: true}
-}
-
-func (v NullableAsyncOperationSubmitResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableAsyncOperationSubmitResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the BackgroundResponse type satisfies the MappedNullable interface at compile time
@@ -155,11 +154,7 @@ func (o *BackgroundResponse) UnsetDisposition() {
 }
 
 func (o BackgroundResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o BackgroundResponse) ToMap() (map[string]interface{}, error) {
@@ -176,24 +171,14 @@ func (o BackgroundResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *BackgroundResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"mission",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varBackgroundResponse := _BackgroundResponse{}
@@ -211,40 +196,10 @@ func (o *BackgroundResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableBackgroundResponse struct {
-	value *BackgroundResponse
-	isSet bool
-}
-
-func (v NullableBackgroundResponse) Get() *BackgroundResponse {
-	return v.value
-}
-
-func (v *NullableBackgroundResponse) Set(val *BackgroundResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableBackgroundResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableBackgroundResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableBackgroundResponse = Nullable[BackgroundResponse]
 
 func NewNullableBackgroundResponse(val *BackgroundResponse) *NullableBackgroundResponse {
-	return &NullableBackgroundResponse{value: val, isSet: true}
-}
-
-func (v NullableBackgroundResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableBackgroundResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
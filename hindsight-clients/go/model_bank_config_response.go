@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the BankConfigResponse type satisfies the MappedNullable interface at compile time
@@ -124,11 +123,7 @@ func (o *BankConfigResponse) SetOverrides(v map[string]interface{}) {
 }
 
 func (o BankConfigResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o BankConfigResponse) ToMap() (map[string]interface{}, error) {
@@ -141,26 +136,16 @@ func (o BankConfigResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *BankConfigResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"bank_id",
 		"config",
 		"overrides",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varBankConfigResponse := _BankConfigResponse{}
@@ -178,40 +163,10 @@ func (o *BankConfigResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableBankConfigResponse struct {
-	value *BankConfigResponse
-	isSet bool
-}
-
-func (v NullableBankConfigResponse) Get() *BankConfigResponse {
-	return v.value
-}
-
-func (v *NullableBankConfigResponse) Set(val *BankConfigResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableBankConfigResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableBankConfigResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableBankConfigResponse = Nullable[BankConfigResponse]
 
 func NewNullableBankConfigResponse(val *BankConfigResponse) *NullableBankConfigResponse {
-	return &NullableBankConfigResponse{value: val, isSet: true}
-}
-
-func (v NullableBankConfigResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableBankConfigResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the BankConfigUpdate type satisfies the MappedNullable interface at compile time
@@ -70,11 +69,7 @@ func (o *BankConfigUpdate) SetUpdates(v map[string]interface{}) {
 }
 
 func (o BankConfigUpdate) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o BankConfigUpdate) ToMap() (map[string]interface{}, error) {
@@ -85,24 +80,14 @@ func (o BankConfigUpdate) ToMap() (map[string]interface{}, error) {
 
 func (o *BankConfigUpdate) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"updates",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varBankConfigUpdate := _BankConfigUpdate{}
@@ -120,40 +105,10 @@ func (o *BankConfigUpdate) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableBankConfigUpdate struct {
-	value *BankConfigUpdate
-	isSet bool
-}
-
-func (v NullableBankConfigUpdate) Get() *BankConfigUpdate {
-	return v.value
-}
-
-func (v *NullableBankConfigUpdate) Set(val *BankConfigUpdate) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableBankConfigUpdate) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableBankConfigUpdate) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableBankConfigUpdate = Nullable[BankConfigUpdate]
 
 func NewNullableBankConfigUpdate(val *BankConfigUpdate) *NullableBankConfigUpdate {
-	return &NullableBankConfigUpdate{value: val, isSet: true}
-}
-
-func (v NullableBankConfigUpdate) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableBankConfigUpdate) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
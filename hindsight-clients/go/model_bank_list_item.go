@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the BankListItem type satisfies the MappedNullable interface at compile time
@@ -267,11 +266,7 @@ func (o *BankListItem) UnsetUpdatedAt() {
 }
 
 func (o BankListItem) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o BankListItem) ToMap() (map[string]interface{}, error) {
@@ -295,25 +290,15 @@ func (o BankListItem) ToMap() (map[string]interface{}, error) {
 
 func (o *BankListItem) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"bank_id",
 		"disposition",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varBankListItem := _BankListItem{}
@@ -331,40 +316,10 @@ func (o *BankListItem) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableBankListItem struct {
-	value *BankListItem
-	isSet bool
-}
-
-func (v NullableBankListItem) Get() *BankListItem {
-	return v.value
-}
-
-func (v *NullableBankListItem) Set(val *BankListItem) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableBankListItem) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableBankListItem) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableBankListItem = Nullable[BankListItem]
 
 func NewNullableBankListItem(val *BankListItem) *NullableBankListItem {
-	return &NullableBankListItem{value: val, isSet: true}
-}
-
-func (v NullableBankListItem) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableBankListItem) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
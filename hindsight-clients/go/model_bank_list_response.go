@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the BankListResponse type satisfies the MappedNullable interface at compile time
@@ -69,11 +68,7 @@ func (o *BankListResponse) SetBanks(v []BankListItem) {
 }
 
 func (o BankListResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o BankListResponse) ToMap() (map[string]interface{}, error) {
@@ -84,24 +79,14 @@ func (o BankListResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *BankListResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"banks",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varBankListResponse := _BankListResponse{}
@@ -119,40 +104,10 @@ func (o *BankListResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableBankListResponse struct {
-	value *BankListResponse
-	isSet bool
-}
-
-func (v NullableBankListResponse) Get() *BankListResponse {
-	return v.value
-}
-
-func (v *NullableBankListResponse) Set(val *BankListResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableBankListResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableBankListResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableBankListResponse = Nullable[BankListResponse]
 
 func NewNullableBankListResponse(val *BankListResponse) *NullableBankListResponse {
-	return &NullableBankListResponse{value: val, isSet: true}
-}
-
-func (v NullableBankListResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableBankListResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the BankProfileResponse type satisfies the MappedNullable interface at compile time
@@ -191,11 +190,7 @@ func (o *BankProfileResponse) UnsetBackground() {
 }
 
 func (o BankProfileResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o BankProfileResponse) ToMap() (map[string]interface{}, error) {
@@ -212,8 +207,7 @@ func (o BankProfileResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *BankProfileResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"bank_id",
 		"name",
@@ -221,18 +215,9 @@ func (o *BankProfileResponse) UnmarshalJSON(data []byte) (err error) {
 		"mission",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varBankProfileResponse := _BankProfileResponse{}
@@ -250,40 +235,10 @@ func (o *BankProfileResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableBankProfileResponse struct {
-	value *BankProfileResponse
-	isSet bool
-}
-
-func (v NullableBankProfileResponse) Get() *BankProfileResponse {
-	return v.value
-}
-
-func (v *NullableBankProfileResponse) Set(val *BankProfileResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableBankProfileResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableBankProfileResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableBankProfileResponse = Nullable[BankProfileResponse]
 
 func NewNullableBankProfileResponse(val *BankProfileResponse) *NullableBankProfileResponse {
-	return &NullableBankProfileResponse{value: val, isSet: true}
-}
-
-func (v NullableBankProfileResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableBankProfileResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
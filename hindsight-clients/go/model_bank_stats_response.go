@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the BankStatsResponse type satisfies the MappedNullable interface at compile time
@@ -422,11 +421,7 @@ func (o *BankStatsResponse) SetTotalObservations(v int32) {
 }
 
 func (o BankStatsResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o BankStatsResponse) ToMap() (map[string]interface{}, error) {
@@ -455,8 +450,7 @@ func (o BankStatsResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *BankStatsResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"bank_id",
 		"total_nodes",
@@ -470,18 +464,9 @@ func (o *BankStatsResponse) UnmarshalJSON(data []byte) (err error) {
 		"failed_operations",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varBankStatsResponse := _BankStatsResponse{}
@@ -499,40 +484,10 @@ func (o *BankStatsResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableBankStatsResponse struct {
-	value *BankStatsResponse
-	isSet bool
-}
-
-func (v NullableBankStatsResponse) Get() *BankStatsResponse {
-	return v.value
-}
-
-func (v *NullableBankStatsResponse) Set(val *BankStatsResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableBankStatsResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableBankStatsResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableBankStatsResponse = Nullable[BankStatsResponse]
 
 func NewNullableBankStatsResponse(val *BankStatsResponse) *NullableBankStatsResponse {
-	return &NullableBankStatsResponse{value: val, isSet: true}
-}
-
-func (v NullableBankStatsResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableBankStatsResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
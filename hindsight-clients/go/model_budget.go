@@ -75,39 +75,9 @@ func (v Budget) Ptr() *Budget {
 	return &v
 }
 
-type NullableBudget struct {
-	value *Budget
-	isSet bool
-}
-
-func (v NullableBudget) Get() *Budget {
-	return v.value
-}
-
-func (v *NullableBudget) Set(val *Budget) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableBudget) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableBudget) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableBudget = Nullable[Budget]
 
 func NewNullableBudget(val *Budget) *NullableBudget {
-	return &NullableBudget{value: val, isSet: true}
-}
-
-func (v NullableBudget) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableBudget) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
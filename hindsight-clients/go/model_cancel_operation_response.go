@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the CancelOperationResponse type satisfies the MappedNullable interface at compile time
@@ -121,11 +120,7 @@ func (o *CancelOperationResponse) SetOperationId(v string) {
 }
 
 func (o CancelOperationResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o CancelOperationResponse) ToMap() (map[string]interface{}, error) {
@@ -138,26 +133,16 @@ func (o CancelOperationResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *CancelOperationResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"success",
 		"message",
 		"operation_id",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varCancelOperationResponse := _CancelOperationResponse{}
@@ -175,40 +160,10 @@ func (o *CancelOperationResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableCancelOperationResponse struct {
-	value *CancelOperationResponse
-	isSet bool
-}
-
-func (v NullableCancelOperationResponse) Get() *CancelOperationResponse {
-	return v.value
-}
-
-func (v *NullableCancelOperationResponse) Set(val *CancelOperationResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableCancelOperationResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableCancelOperationResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableCancelOperationResponse = Nullable[CancelOperationResponse]
 
 func NewNullableCancelOperationResponse(val *CancelOperationResponse) *NullableCancelOperationResponse {
-	return &NullableCancelOperationResponse{value: val, isSet: true}
-}
-
-func (v NullableCancelOperationResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableCancelOperationResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
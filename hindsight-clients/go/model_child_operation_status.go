@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ChildOperationStatus type satisfies the MappedNullable interface at compile time
@@ -22,7 +21,7 @@ var _ MappedNullable = &ChildOperationStatus{}
 // ChildOperationStatus Status of a child operation (for batch operations).
 type ChildOperationStatus struct {
 	OperationId string `json:"operation_id"`
-	Status string `json:"status"`
+	Status OperationStatus `json:"status"`
 	SubBatchIndex NullableInt32 `json:"sub_batch_index,omitempty"`
 	ItemsCount NullableInt32 `json:"items_count,omitempty"`
 	ErrorMessage NullableString `json:"error_message,omitempty"`
@@ -34,7 +33,7 @@ type _ChildOperationStatus ChildOperationStatus
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewChildOperationStatus(operationId string, status string) *ChildOperationStatus {
+func NewChildOperationStatus(operationId string, status OperationStatus) *ChildOperationStatus {
 	this := ChildOperationStatus{}
 	this.OperationId = operationId
 	this.Status = status
@@ -74,9 +73,9 @@ func (o *ChildOperationStatus) SetOperationId(v string) {
 }
 
 // GetStatus returns the Status field value
-func (o *ChildOperationStatus) GetStatus() string {
+func (o *ChildOperationStatus) GetStatus() OperationStatus {
 	if o == nil {
-		var ret string
+		var ret OperationStatus
 		return ret
 	}
 
@@ -85,7 +84,7 @@ func (o *ChildOperationStatus) GetStatus() string {
 
 // GetStatusOk returns a tuple with the Status field value
 // and a boolean to check if the value has been set.
-func (o *ChildOperationStatus) GetStatusOk() (*string, bool) {
+func (o *ChildOperationStatus) GetStatusOk() (*OperationStatus, bool) {
 	if o == nil {
 		return nil, false
 	}
@@ -93,7 +92,7 @@ func (o *ChildOperationStatus) GetStatusOk() (*string, bool) {
 }
 
 // SetStatus sets field value
-func (o *ChildOperationStatus) SetStatus(v string) {
+func (o *ChildOperationStatus) SetStatus(v OperationStatus) {
 	o.Status = v
 }
 
@@ -224,11 +223,7 @@ func (o *ChildOperationStatus) UnsetErrorMessage() {
 }
 
 func (o ChildOperationStatus) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ChildOperationStatus) ToMap() (map[string]interface{}, error) {
@@ -249,25 +244,15 @@ func (o ChildOperationStatus) ToMap() (map[string]interface{}, error) {
 
 func (o *ChildOperationStatus) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"operation_id",
 		"status",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varChildOperationStatus := _ChildOperationStatus{}
@@ -285,40 +270,10 @@ func (o *ChildOperationStatus) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableChildOperationStatus struct {
-	value *ChildOperationStatus
-	isSet bool
-}
-
-func (v NullableChildOperationStatus) Get() *ChildOperationStatus {
-	return v.value
-}
-
-func (v *NullableChildOperationStatus) Set(val *ChildOperationStatus) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableChildOperationStatus) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableChildOperationStatus) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableChildOperationStatus = Nullable[ChildOperationStatus]
 
 func NewNullableChildOperationStatus(val *ChildOperationStatus) *NullableChildOperationStatus {
-	return &NullableChildOperationStatus{value: val, isSet: true}
-}
-
-func (v NullableChildOperationStatus) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableChildOperationStatus) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
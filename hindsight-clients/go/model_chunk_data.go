@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ChunkData type satisfies the MappedNullable interface at compile time
@@ -159,11 +158,7 @@ func (o *ChunkData) SetTruncated(v bool) {
 }
 
 func (o ChunkData) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ChunkData) ToMap() (map[string]interface{}, error) {
@@ -179,26 +174,16 @@ func (o ChunkData) ToMap() (map[string]interface{}, error) {
 
 func (o *ChunkData) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"id",
 		"text",
 		"chunk_index",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varChunkData := _ChunkData{}
@@ -216,40 +201,10 @@ func (o *ChunkData) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableChunkData struct {
-	value *ChunkData
-	isSet bool
-}
-
-func (v NullableChunkData) Get() *ChunkData {
-	return v.value
-}
-
-func (v *NullableChunkData) Set(val *ChunkData) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableChunkData) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableChunkData) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableChunkData = Nullable[ChunkData]
 
 func NewNullableChunkData(val *ChunkData) *NullableChunkData {
-	return &NullableChunkData{value: val, isSet: true}
-}
-
-func (v NullableChunkData) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableChunkData) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the ChunkIncludeOptions type satisfies the MappedNullable interface at compile time
@@ -77,11 +76,7 @@ func (o *ChunkIncludeOptions) SetMaxTokens(v int32) {
 }
 
 func (o ChunkIncludeOptions) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ChunkIncludeOptions) ToMap() (map[string]interface{}, error) {
@@ -92,40 +87,10 @@ func (o ChunkIncludeOptions) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableChunkIncludeOptions struct {
-	value *ChunkIncludeOptions
-	isSet bool
-}
-
-func (v NullableChunkIncludeOptions) Get() *ChunkIncludeOptions {
-	return v.value
-}
-
-func (v *NullableChunkIncludeOptions) Set(val *ChunkIncludeOptions) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableChunkIncludeOptions) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableChunkIncludeOptions) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableChunkIncludeOptions = Nullable[ChunkIncludeOptions]
 
 func NewNullableChunkIncludeOptions(val *ChunkIncludeOptions) *NullableChunkIncludeOptions {
-	return &NullableChunkIncludeOptions{value: val, isSet: true}
-}
-
-func (v NullableChunkIncludeOptions) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableChunkIncludeOptions) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
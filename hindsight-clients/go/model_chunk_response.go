@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ChunkResponse type satisfies the MappedNullable interface at compile time
@@ -199,11 +198,7 @@ func (o *ChunkResponse) SetCreatedAt(v string) {
 }
 
 func (o ChunkResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ChunkResponse) ToMap() (map[string]interface{}, error) {
@@ -219,8 +214,7 @@ func (o ChunkResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *ChunkResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"chunk_id",
 		"document_id",
@@ -230,18 +224,9 @@ func (o *ChunkResponse) UnmarshalJSON(data []byte) (err error) {
 		"created_at",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varChunkResponse := _ChunkResponse{}
@@ -259,40 +244,10 @@ func (o *ChunkResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableChunkResponse struct {
-	value *ChunkResponse
-	isSet bool
-}
-
-func (v NullableChunkResponse) Get() *ChunkResponse {
-	return v.value
-}
-
-func (v *NullableChunkResponse) Set(val *ChunkResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableChunkResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableChunkResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableChunkResponse = Nullable[ChunkResponse]
 
 func NewNullableChunkResponse(val *ChunkResponse) *NullableChunkResponse {
-	return &NullableChunkResponse{value: val, isSet: true}
-}
-
-func (v NullableChunkResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableChunkResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
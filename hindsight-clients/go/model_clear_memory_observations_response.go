@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ClearMemoryObservationsResponse type satisfies the MappedNullable interface at compile time
@@ -69,11 +68,7 @@ func (o *ClearMemoryObservationsResponse) SetDeletedCount(v int32) {
 }
 
 func (o ClearMemoryObservationsResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ClearMemoryObservationsResponse) ToMap() (map[string]interface{}, error) {
@@ -84,24 +79,14 @@ func (o ClearMemoryObservationsResponse) ToMap() (map[string]interface{}, error)
 
 func (o *ClearMemoryObservationsResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"deleted_count",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varClearMemoryObservationsResponse := _ClearMemoryObservationsResponse{}
@@ -119,40 +104,10 @@ func (o *ClearMemoryObservationsResponse) UnmarshalJSON(data []byte) (err error)
 	return err
 }
 
-type NullableClearMemoryObservationsResponse struct {
-	value *ClearMemoryObservationsResponse
-	isSet bool
-}
-
-func (v NullableClearMemoryObservationsResponse) Get() *ClearMemoryObservationsResponse {
-	return v.value
-}
-
-func (v *NullableClearMemoryObservationsResponse) Set(val *ClearMemoryObservationsResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableClearMemoryObservationsResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableClearMemoryObservationsResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableClearMemoryObservationsResponse = Nullable[ClearMemoryObservationsResponse]
 
 func NewNullableClearMemoryObservationsResponse(val *ClearMemoryObservationsResponse) *NullableClearMemoryObservationsResponse {
-	return &NullableClearMemoryObservationsResponse{value: val, isSet: true}
-}
-
-func (v NullableClearMemoryObservationsResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableClearMemoryObservationsResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ConsolidationResponse type satisfies the MappedNullable interface at compile time
@@ -108,11 +107,7 @@ func (o *ConsolidationResponse) SetDeduplicated(v bool) {
 }
 
 func (o ConsolidationResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ConsolidationResponse) ToMap() (map[string]interface{}, error) {
@@ -126,24 +121,14 @@ func (o ConsolidationResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *ConsolidationResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"operation_id",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varConsolidationResponse := _ConsolidationResponse{}
@@ -161,40 +146,10 @@ func (o *ConsolidationResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableConsolidationResponse struct {
-	value *ConsolidationResponse
-	isSet bool
-}
-
-func (v NullableConsolidationResponse) Get() *ConsolidationResponse {
-	return v.value
-}
-
-func (v *NullableConsolidationResponse) Set(val *ConsolidationResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableConsolidationResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableConsolidationResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableConsolidationResponse = Nullable[ConsolidationResponse]
 
 func NewNullableConsolidationResponse(val *ConsolidationResponse) *NullableConsolidationResponse {
-	return &NullableConsolidationResponse{value: val, isSet: true}
-}
-
-func (v NullableConsolidationResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableConsolidationResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
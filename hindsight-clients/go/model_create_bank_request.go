@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the CreateBankRequest type satisfies the MappedNullable interface at compile time
@@ -28,7 +27,7 @@ type CreateBankRequest struct {
 	Background NullableString `json:"background,omitempty"`
 	ReflectMission NullableString `json:"reflect_mission,omitempty"`
 	RetainMission NullableString `json:"retain_mission,omitempty"`
-	RetainExtractionMode NullableString `json:"retain_extraction_mode,omitempty"`
+	RetainExtractionMode NullableRetainExtractionMode `json:"retain_extraction_mode,omitempty"`
 	RetainCustomInstructions NullableString `json:"retain_custom_instructions,omitempty"`
 	RetainChunkSize NullableInt32 `json:"retain_chunk_size,omitempty"`
 	EnableObservations NullableBool `json:"enable_observations,omitempty"`
@@ -431,9 +430,9 @@ func (o *CreateBankRequest) UnsetRetainMission() {
 }
 
 // GetRetainExtractionMode returns the RetainExtractionMode field value if set, zero value otherwise (both if not set or set to explicit null).
-func (o *CreateBankRequest) GetRetainExtractionMode() string {
+func (o *CreateBankRequest) GetRetainExtractionMode() RetainExtractionMode {
 	if o == nil || IsNil(o.RetainExtractionMode.Get()) {
-		var ret string
+		var ret RetainExtractionMode
 		return ret
 	}
 	return *o.RetainExtractionMode.Get()
@@ -442,7 +441,7 @@ func (o *CreateBankRequest) GetRetainExtractionMode() string {
 // GetRetainExtractionModeOk returns a tuple with the RetainExtractionMode field value if set, nil otherwise
 // and a boolean to check if the value has been set.
 // NOTE: If the value is an explicit nil, `nil, true` will be returned
-func (o *CreateBankRequest) GetRetainExtractionModeOk() (*string, bool) {
+func (o *CreateBankRequest) GetRetainExtractionModeOk() (*RetainExtractionMode, bool) {
 	if o == nil {
 		return nil, false
 	}
@@ -458,8 +457,8 @@ func (o *CreateBankRequest) HasRetainExtractionMode() bool {
 	return false
 }
 
-// SetRetainExtractionMode gets a reference to the given NullableString and assigns it to the RetainExtractionMode field.
-func (o *CreateBankRequest) SetRetainExtractionMode(v string) {
+// SetRetainExtractionMode gets a reference to the given NullableRetainExtractionMode and assigns it to the RetainExtractionMode field.
+func (o *CreateBankRequest) SetRetainExtractionMode(v RetainExtractionMode) {
 	o.RetainExtractionMode.Set(&v)
 }
 // SetRetainExtractionModeNil sets the value for RetainExtractionMode to be an explicit nil
@@ -641,11 +640,7 @@ func (o *CreateBankRequest) UnsetObservationsMission() {
 }
 
 func (o CreateBankRequest) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o CreateBankRequest) ToMap() (map[string]interface{}, error) {
@@ -695,40 +690,10 @@ func (o CreateBankRequest) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableCreateBankRequest struct {
-	value *CreateBankRequest
-	isSet bool
-}
-
-func (v NullableCreateBankRequest) Get() *CreateBankRequest {
-	return v.value
-}
-
-func (v *NullableCreateBankRequest) Set(val *CreateBankRequest) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableCreateBankRequest) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableCreateBankRequest) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableCreateBankRequest = Nullable[CreateBankRequest]
 
 func NewNullableCreateBankRequest(val *CreateBankRequest) *NullableCreateBankRequest {
-	return &NullableCreateBankRequest{value: val, isSet: true}
-}
-
-func (v NullableCreateBankRequest) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableCreateBankRequest) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the CreateDirectiveRequest type satisfies the MappedNullable interface at compile time
@@ -207,11 +206,7 @@ func (o *CreateDirectiveRequest) SetTags(v []string) {
 }
 
 func (o CreateDirectiveRequest) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o CreateDirectiveRequest) ToMap() (map[string]interface{}, error) {
@@ -232,25 +227,15 @@ func (o CreateDirectiveRequest) ToMap() (map[string]interface{}, error) {
 
 func (o *CreateDirectiveRequest) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"name",
 		"content",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varCreateDirectiveRequest := _CreateDirectiveRequest{}
@@ -268,40 +253,10 @@ func (o *CreateDirectiveRequest) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableCreateDirectiveRequest struct {
-	value *CreateDirectiveRequest
-	isSet bool
-}
-
-func (v NullableCreateDirectiveRequest) Get() *CreateDirectiveRequest {
-	return v.value
-}
-
-func (v *NullableCreateDirectiveRequest) Set(val *CreateDirectiveRequest) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableCreateDirectiveRequest) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableCreateDirectiveRequest) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableCreateDirectiveRequest = Nullable[CreateDirectiveRequest]
 
 func NewNullableCreateDirectiveRequest(val *CreateDirectiveRequest) *NullableCreateDirectiveRequest {
-	return &NullableCreateDirectiveRequest{value: val, isSet: true}
-}
-
-func (v NullableCreateDirectiveRequest) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableCreateDirectiveRequest) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
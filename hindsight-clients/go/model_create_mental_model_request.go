@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the CreateMentalModelRequest type satisfies the MappedNullable interface at compile time
@@ -246,11 +245,7 @@ func (o *CreateMentalModelRequest) SetTrigger(v MentalModelTrigger) {
 }
 
 func (o CreateMentalModelRequest) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o CreateMentalModelRequest) ToMap() (map[string]interface{}, error) {
@@ -274,25 +269,15 @@ func (o CreateMentalModelRequest) ToMap() (map[string]interface{}, error) {
 
 func (o *CreateMentalModelRequest) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"name",
 		"source_query",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varCreateMentalModelRequest := _CreateMentalModelRequest{}
@@ -310,40 +295,10 @@ func (o *CreateMentalModelRequest) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableCreateMentalModelRequest struct {
-	value *CreateMentalModelRequest
-	isSet bool
-}
-
-func (v NullableCreateMentalModelRequest) Get() *CreateMentalModelRequest {
-	return v.value
-}
-
-func (v *NullableCreateMentalModelRequest) Set(val *CreateMentalModelRequest) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableCreateMentalModelRequest) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableCreateMentalModelRequest) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableCreateMentalModelRequest = Nullable[CreateMentalModelRequest]
 
 func NewNullableCreateMentalModelRequest(val *CreateMentalModelRequest) *NullableCreateMentalModelRequest {
-	return &NullableCreateMentalModelRequest{value: val, isSet: true}
-}
-
-func (v NullableCreateMentalModelRequest) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableCreateMentalModelRequest) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the CreateMentalModelResponse type satisfies the MappedNullable interface at compile time
@@ -113,11 +112,7 @@ func (o *CreateMentalModelResponse) SetOperationId(v string) {
 }
 
 func (o CreateMentalModelResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o CreateMentalModelResponse) ToMap() (map[string]interface{}, error) {
@@ -131,24 +126,14 @@ func (o CreateMentalModelResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *CreateMentalModelResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"operation_id",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varCreateMentalModelResponse := _CreateMentalModelResponse{}
@@ -166,40 +151,10 @@ func (o *CreateMentalModelResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableCreateMentalModelResponse struct {
-	value *CreateMentalModelResponse
-	isSet bool
-}
-
-func (v NullableCreateMentalModelResponse) Get() *CreateMentalModelResponse {
-	return v.value
-}
-
-func (v *NullableCreateMentalModelResponse) Set(val *CreateMentalModelResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableCreateMentalModelResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableCreateMentalModelResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableCreateMentalModelResponse = Nullable[CreateMentalModelResponse]
 
 func NewNullableCreateMentalModelResponse(val *CreateMentalModelResponse) *NullableCreateMentalModelResponse {
-	return &NullableCreateMentalModelResponse{value: val, isSet: true}
-}
-
-func (v NullableCreateMentalModelResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableCreateMentalModelResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the DeleteDocumentResponse type satisfies the MappedNullable interface at compile time
@@ -147,11 +146,7 @@ func (o *DeleteDocumentResponse) SetMemoryUnitsDeleted(v int32) {
 }
 
 func (o DeleteDocumentResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o DeleteDocumentResponse) ToMap() (map[string]interface{}, error) {
@@ -165,8 +160,7 @@ func (o DeleteDocumentResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *DeleteDocumentResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"success",
 		"message",
@@ -174,18 +168,9 @@ func (o *DeleteDocumentResponse) UnmarshalJSON(data []byte) (err error) {
 		"memory_units_deleted",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varDeleteDocumentResponse := _DeleteDocumentResponse{}
@@ -203,40 +188,10 @@ func (o *DeleteDocumentResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableDeleteDocumentResponse struct {
-	value *DeleteDocumentResponse
-	isSet bool
-}
-
-func (v NullableDeleteDocumentResponse) Get() *DeleteDocumentResponse {
-	return v.value
-}
-
-func (v *NullableDeleteDocumentResponse) Set(val *DeleteDocumentResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableDeleteDocumentResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableDeleteDocumentResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableDeleteDocumentResponse = Nullable[DeleteDocumentResponse]
 
 func NewNullableDeleteDocumentResponse(val *DeleteDocumentResponse) *NullableDeleteDocumentResponse {
-	return &NullableDeleteDocumentResponse{value: val, isSet: true}
-}
-
-func (v NullableDeleteDocumentResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableDeleteDocumentResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
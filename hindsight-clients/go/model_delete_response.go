@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the DeleteResponse type satisfies the MappedNullable interface at compile time
@@ -155,11 +154,7 @@ func (o *DeleteResponse) UnsetDeletedCount() {
 }
 
 func (o DeleteResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o DeleteResponse) ToMap() (map[string]interface{}, error) {
@@ -176,24 +171,14 @@ func (o DeleteResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *DeleteResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"success",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varDeleteResponse := _DeleteResponse{}
@@ -211,40 +196,10 @@ func (o *DeleteResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableDeleteResponse struct {
-	value *DeleteResponse
-	isSet bool
-}
-
-func (v NullableDeleteResponse) Get() *DeleteResponse {
-	return v.value
-}
-
-func (v *NullableDeleteResponse) Set(val *DeleteResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableDeleteResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableDeleteResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableDeleteResponse = Nullable[DeleteResponse]
 
 func NewNullableDeleteResponse(val *DeleteResponse) *NullableDeleteResponse {
-	return &NullableDeleteResponse{value: val, isSet: true}
-}
-
-func (v NullableDeleteResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableDeleteResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the DirectiveListResponse type satisfies the MappedNullable interface at compile time
@@ -69,11 +68,7 @@ func (o *DirectiveListResponse) SetItems(v []DirectiveResponse) {
 }
 
 func (o DirectiveListResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o DirectiveListResponse) ToMap() (map[string]interface{}, error) {
@@ -84,24 +79,14 @@ func (o DirectiveListResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *DirectiveListResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"items",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varDirectiveListResponse := _DirectiveListResponse{}
@@ -119,40 +104,10 @@ func (o *DirectiveListResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableDirectiveListResponse struct {
-	value *DirectiveListResponse
-	isSet bool
-}
-
-func (v NullableDirectiveListResponse) Get() *DirectiveListResponse {
-	return v.value
-}
-
-func (v *NullableDirectiveListResponse) Set(val *DirectiveListResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableDirectiveListResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableDirectiveListResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableDirectiveListResponse = Nullable[DirectiveListResponse]
 
 func NewNullableDirectiveListResponse(val *DirectiveListResponse) *NullableDirectiveListResponse {
-	return &NullableDirectiveListResponse{value: val, isSet: true}
-}
-
-func (v NullableDirectiveListResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableDirectiveListResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
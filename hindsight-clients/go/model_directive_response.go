@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the DirectiveResponse type satisfies the MappedNullable interface at compile time
@@ -340,11 +339,7 @@ func (o *DirectiveResponse) UnsetUpdatedAt() {
 }
 
 func (o DirectiveResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o DirectiveResponse) ToMap() (map[string]interface{}, error) {
@@ -373,8 +368,7 @@ func (o DirectiveResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *DirectiveResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"id",
 		"bank_id",
@@ -382,18 +376,9 @@ func (o *DirectiveResponse) UnmarshalJSON(data []byte) (err error) {
 		"content",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varDirectiveResponse := _DirectiveResponse{}
@@ -411,40 +396,10 @@ func (o *DirectiveResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableDirectiveResponse struct {
-	value *DirectiveResponse
-	isSet bool
-}
-
-func (v NullableDirectiveResponse) Get() *DirectiveResponse {
-	return v.value
-}
-
-func (v *NullableDirectiveResponse) Set(val *DirectiveResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableDirectiveResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableDirectiveResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableDirectiveResponse = Nullable[DirectiveResponse]
 
 func NewNullableDirectiveResponse(val *DirectiveResponse) *NullableDirectiveResponse {
-	return &NullableDirectiveResponse{value: val, isSet: true}
-}
-
-func (v NullableDirectiveResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableDirectiveResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the DispositionTraits type satisfies the MappedNullable interface at compile time
@@ -124,11 +123,7 @@ func (o *DispositionTraits) SetEmpathy(v int32) {
 }
 
 func (o DispositionTraits) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o DispositionTraits) ToMap() (map[string]interface{}, error) {
@@ -141,26 +136,16 @@ func (o DispositionTraits) ToMap() (map[string]interface{}, error) {
 
 func (o *DispositionTraits) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"skepticism",
 		"literalism",
 		"empathy",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varDispositionTraits := _DispositionTraits{}
@@ -178,40 +163,10 @@ func (o *DispositionTraits) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableDispositionTraits struct {
-	value *DispositionTraits
-	isSet bool
-}
-
-func (v NullableDispositionTraits) Get() *DispositionTraits {
-	return v.value
-}
-
-func (v *NullableDispositionTraits) Set(val *DispositionTraits) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableDispositionTraits) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableDispositionTraits) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableDispositionTraits = Nullable[DispositionTraits]
 
 func NewNullableDispositionTraits(val *DispositionTraits) *NullableDispositionTraits {
-	return &NullableDispositionTraits{value: val, isSet: true}
-}
-
-func (v NullableDispositionTraits) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableDispositionTraits) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the DocumentResponse type satisfies the MappedNullable interface at compile time
@@ -261,11 +260,7 @@ func (o *DocumentResponse) SetTags(v []string) {
 }
 
 func (o DocumentResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o DocumentResponse) ToMap() (map[string]interface{}, error) {
@@ -285,8 +280,7 @@ func (o DocumentResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *DocumentResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"id",
 		"bank_id",
@@ -297,18 +291,9 @@ func (o *DocumentResponse) UnmarshalJSON(data []byte) (err error) {
 		"memory_unit_count",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varDocumentResponse := _DocumentResponse{}
@@ -326,40 +311,10 @@ func (o *DocumentResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableDocumentResponse struct {
-	value *DocumentResponse
-	isSet bool
-}
-
-func (v NullableDocumentResponse) Get() *DocumentResponse {
-	return v.value
-}
-
-func (v *NullableDocumentResponse) Set(val *DocumentResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableDocumentResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableDocumentResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableDocumentResponse = Nullable[DocumentResponse]
 
 func NewNullableDocumentResponse(val *DocumentResponse) *NullableDocumentResponse {
-	return &NullableDocumentResponse{value: val, isSet: true}
-}
-
-func (v NullableDocumentResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableDocumentResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the EntityDetailResponse type satisfies the MappedNullable interface at compile time
@@ -267,11 +266,7 @@ func (o *EntityDetailResponse) SetObservations(v []EntityObservationResponse) {
 }
 
 func (o EntityDetailResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o EntityDetailResponse) ToMap() (map[string]interface{}, error) {
@@ -294,8 +289,7 @@ func (o EntityDetailResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *EntityDetailResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"id",
 		"canonical_name",
@@ -303,18 +297,9 @@ func (o *EntityDetailResponse) UnmarshalJSON(data []byte) (err error) {
 		"observations",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varEntityDetailResponse := _EntityDetailResponse{}
@@ -332,40 +317,10 @@ func (o *EntityDetailResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableEntityDetailResponse struct {
-	value *EntityDetailResponse
-	isSet bool
-}
-
-func (v NullableEntityDetailResponse) Get() *EntityDetailResponse {
-	return v.value
-}
-
-func (v *NullableEntityDetailResponse) Set(val *EntityDetailResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableEntityDetailResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableEntityDetailResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableEntityDetailResponse = Nullable[EntityDetailResponse]
 
 func NewNullableEntityDetailResponse(val *EntityDetailResponse) *NullableEntityDetailResponse {
-	return &NullableEntityDetailResponse{value: val, isSet: true}
-}
-
-func (v NullableEntityDetailResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableEntityDetailResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
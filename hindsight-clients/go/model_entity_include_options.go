@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the EntityIncludeOptions type satisfies the MappedNullable interface at compile time
@@ -77,11 +76,7 @@ func (o *EntityIncludeOptions) SetMaxTokens(v int32) {
 }
 
 func (o EntityIncludeOptions) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o EntityIncludeOptions) ToMap() (map[string]interface{}, error) {
@@ -92,40 +87,10 @@ func (o EntityIncludeOptions) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableEntityIncludeOptions struct {
-	value *EntityIncludeOptions
-	isSet bool
-}
-
-func (v NullableEntityIncludeOptions) Get() *EntityIncludeOptions {
-	return v.value
-}
-
-func (v *NullableEntityIncludeOptions) Set(val *EntityIncludeOptions) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableEntityIncludeOptions) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableEntityIncludeOptions) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableEntityIncludeOptions = Nullable[EntityIncludeOptions]
 
 func NewNullableEntityIncludeOptions(val *EntityIncludeOptions) *NullableEntityIncludeOptions {
-	return &NullableEntityIncludeOptions{value: val, isSet: true}
-}
-
-func (v NullableEntityIncludeOptions) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableEntityIncludeOptions) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the EntityInput type satisfies the MappedNullable interface at compile time
@@ -113,11 +112,7 @@ func (o *EntityInput) UnsetType() {
 }
 
 func (o EntityInput) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o EntityInput) ToMap() (map[string]interface{}, error) {
@@ -131,24 +126,14 @@ func (o EntityInput) ToMap() (map[string]interface{}, error) {
 
 func (o *EntityInput) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"text",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varEntityInput := _EntityInput{}
@@ -166,40 +151,10 @@ func (o *EntityInput) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableEntityInput struct {
-	value *EntityInput
-	isSet bool
-}
-
-func (v NullableEntityInput) Get() *EntityInput {
-	return v.value
-}
-
-func (v *NullableEntityInput) Set(val *EntityInput) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableEntityInput) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableEntityInput) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableEntityInput = Nullable[EntityInput]
 
 func NewNullableEntityInput(val *EntityInput) *NullableEntityInput {
-	return &NullableEntityInput{value: val, isSet: true}
-}
-
-func (v NullableEntityInput) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableEntityInput) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the EntityListItem type satisfies the MappedNullable interface at compile time
@@ -241,11 +240,7 @@ func (o *EntityListItem) SetMetadata(v map[string]interface{}) {
 }
 
 func (o EntityListItem) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o EntityListItem) ToMap() (map[string]interface{}, error) {
@@ -267,26 +262,16 @@ func (o EntityListItem) ToMap() (map[string]interface{}, error) {
 
 func (o *EntityListItem) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"id",
 		"canonical_name",
 		"mention_count",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varEntityListItem := _EntityListItem{}
@@ -304,40 +289,10 @@ func (o *EntityListItem) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableEntityListItem struct {
-	value *EntityListItem
-	isSet bool
-}
-
-func (v NullableEntityListItem) Get() *EntityListItem {
-	return v.value
-}
-
-func (v *NullableEntityListItem) Set(val *EntityListItem) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableEntityListItem) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableEntityListItem) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableEntityListItem = Nullable[EntityListItem]
 
 func NewNullableEntityListItem(val *EntityListItem) *NullableEntityListItem {
-	return &NullableEntityListItem{value: val, isSet: true}
-}
-
-func (v NullableEntityListItem) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableEntityListItem) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the EntityListResponse type satisfies the MappedNullable interface at compile time
@@ -147,11 +146,7 @@ func (o *EntityListResponse) SetOffset(v int32) {
 }
 
 func (o EntityListResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o EntityListResponse) ToMap() (map[string]interface{}, error) {
@@ -165,8 +160,7 @@ func (o EntityListResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *EntityListResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"items",
 		"total",
@@ -174,18 +168,9 @@ func (o *EntityListResponse) UnmarshalJSON(data []byte) (err error) {
 		"offset",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varEntityListResponse := _EntityListResponse{}
@@ -203,40 +188,10 @@ func (o *EntityListResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableEntityListResponse struct {
-	value *EntityListResponse
-	isSet bool
-}
-
-func (v NullableEntityListResponse) Get() *EntityListResponse {
-	return v.value
-}
-
-func (v *NullableEntityListResponse) Set(val *EntityListResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableEntityListResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableEntityListResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableEntityListResponse = Nullable[EntityListResponse]
 
 func NewNullableEntityListResponse(val *EntityListResponse) *NullableEntityListResponse {
-	return &NullableEntityListResponse{value: val, isSet: true}
-}
-
-func (v NullableEntityListResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableEntityListResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the EntityObservationResponse type satisfies the MappedNullable interface at compile time
@@ -112,11 +111,7 @@ func (o *EntityObservationResponse) UnsetMentionedAt() {
 }
 
 func (o EntityObservationResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o EntityObservationResponse) ToMap() (map[string]interface{}, error) {
@@ -130,24 +125,14 @@ func (o EntityObservationResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *EntityObservationResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"text",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varEntityObservationResponse := _EntityObservationResponse{}
@@ -165,40 +150,10 @@ func (o *EntityObservationResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableEntityObservationResponse struct {
-	value *EntityObservationResponse
-	isSet bool
-}
-
-func (v NullableEntityObservationResponse) Get() *EntityObservationResponse {
-	return v.value
-}
-
-func (v *NullableEntityObservationResponse) Set(val *EntityObservationResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableEntityObservationResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableEntityObservationResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableEntityObservationResponse = Nullable[EntityObservationResponse]
 
 func NewNullableEntityObservationResponse(val *EntityObservationResponse) *NullableEntityObservationResponse {
-	return &NullableEntityObservationResponse{value: val, isSet: true}
-}
-
-func (v NullableEntityObservationResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableEntityObservationResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
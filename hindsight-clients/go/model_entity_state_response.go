@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the EntityStateResponse type satisfies the MappedNullable interface at compile time
@@ -121,11 +120,7 @@ func (o *EntityStateResponse) SetObservations(v []EntityObservationResponse) {
 }
 
 func (o EntityStateResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o EntityStateResponse) ToMap() (map[string]interface{}, error) {
@@ -138,26 +133,16 @@ func (o EntityStateResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *EntityStateResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"entity_id",
 		"canonical_name",
 		"observations",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varEntityStateResponse := _EntityStateResponse{}
@@ -175,40 +160,10 @@ func (o *EntityStateResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableEntityStateResponse struct {
-	value *EntityStateResponse
-	isSet bool
-}
-
-func (v NullableEntityStateResponse) Get() *EntityStateResponse {
-	return v.value
-}
-
-func (v *NullableEntityStateResponse) Set(val *EntityStateResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableEntityStateResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableEntityStateResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableEntityStateResponse = Nullable[EntityStateResponse]
 
 func NewNullableEntityStateResponse(val *EntityStateResponse) *NullableEntityStateResponse {
-	return &NullableEntityStateResponse{value: val, isSet: true}
-}
-
-func (v NullableEntityStateResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableEntityStateResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the FeaturesInfo type satisfies the MappedNullable interface at compile time
@@ -178,11 +177,7 @@ func (o *FeaturesInfo) SetFileUploadApi(v bool) {
 }
 
 func (o FeaturesInfo) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o FeaturesInfo) ToMap() (map[string]interface{}, error) {
@@ -197,8 +192,7 @@ func (o FeaturesInfo) ToMap() (map[string]interface{}, error) {
 
 func (o *FeaturesInfo) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"observations",
 		"mcp",
@@ -207,18 +201,9 @@ func (o *FeaturesInfo) UnmarshalJSON(data []byte) (err error) {
 		"file_upload_api",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varFeaturesInfo := _FeaturesInfo{}
@@ -236,40 +221,10 @@ func (o *FeaturesInfo) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableFeaturesInfo struct {
-	value *FeaturesInfo
-	isSet bool
-}
-
-func (v NullableFeaturesInfo) Get() *FeaturesInfo {
-	return v.value
-}
-
-func (v *NullableFeaturesInfo) Set(val *FeaturesInfo) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableFeaturesInfo) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableFeaturesInfo) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableFeaturesInfo = Nullable[FeaturesInfo]
 
 func NewNullableFeaturesInfo(val *FeaturesInfo) *NullableFeaturesInfo {
-	return &NullableFeaturesInfo{value: val, isSet: true}
-}
-
-func (v NullableFeaturesInfo) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableFeaturesInfo) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
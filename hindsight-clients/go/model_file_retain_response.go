@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the FileRetainResponse type satisfies the MappedNullable interface at compile time
@@ -70,11 +69,7 @@ func (o *FileRetainResponse) SetOperationIds(v []string) {
 }
 
 func (o FileRetainResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o FileRetainResponse) ToMap() (map[string]interface{}, error) {
@@ -85,24 +80,14 @@ func (o FileRetainResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *FileRetainResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"operation_ids",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varFileRetainResponse := _FileRetainResponse{}
@@ -120,40 +105,10 @@ func (o *FileRetainResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableFileRetainResponse struct {
-	value *FileRetainResponse
-	isSet bool
-}
-
-func (v NullableFileRetainResponse) Get() *FileRetainResponse {
-	return v.value
-}
-
-func (v *NullableFileRetainResponse) Set(val *FileRetainResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableFileRetainResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableFileRetainResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableFileRetainResponse = Nullable[FileRetainResponse]
 
 func NewNullableFileRetainResponse(val *FileRetainResponse) *NullableFileRetainResponse {
-	return &NullableFileRetainResponse{value: val, isSet: true}
-}
-
-func (v NullableFileRetainResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableFileRetainResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the GraphDataResponse type satisfies the MappedNullable interface at compile time
@@ -173,11 +172,7 @@ func (o *GraphDataResponse) SetLimit(v int32) {
 }
 
 func (o GraphDataResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o GraphDataResponse) ToMap() (map[string]interface{}, error) {
@@ -192,8 +187,7 @@ func (o GraphDataResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *GraphDataResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"nodes",
 		"edges",
@@ -202,18 +196,9 @@ func (o *GraphDataResponse) UnmarshalJSON(data []byte) (err error) {
 		"limit",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varGraphDataResponse := _GraphDataResponse{}
@@ -231,40 +216,10 @@ func (o *GraphDataResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableGraphDataResponse struct {
-	value *GraphDataResponse
-	isSet bool
-}
-
-func (v NullableGraphDataResponse) Get() *GraphDataResponse {
-	return v.value
-}
-
-func (v *NullableGraphDataResponse) Set(val *GraphDataResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableGraphDataResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableGraphDataResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableGraphDataResponse = Nullable[GraphDataResponse]
 
 func NewNullableGraphDataResponse(val *GraphDataResponse) *NullableGraphDataResponse {
-	return &NullableGraphDataResponse{value: val, isSet: true}
-}
-
-func (v NullableGraphDataResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableGraphDataResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the HTTPValidationError type satisfies the MappedNullable interface at compile time
@@ -72,11 +71,7 @@ func (o *HTTPValidationError) SetDetail(v []ValidationError) {
 }
 
 func (o HTTPValidationError) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o HTTPValidationError) ToMap() (map[string]interface{}, error) {
@@ -87,40 +82,10 @@ func (o HTTPValidationError) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableHTTPValidationError struct {
-	value *HTTPValidationError
-	isSet bool
-}
-
-func (v NullableHTTPValidationError) Get() *HTTPValidationError {
-	return v.value
-}
-
-func (v *NullableHTTPValidationError) Set(val *HTTPValidationError) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableHTTPValidationError) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableHTTPValidationError) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableHTTPValidationError = Nullable[HTTPValidationError]
 
 func NewNullableHTTPValidationError(val *HTTPValidationError) *NullableHTTPValidationError {
-	return &NullableHTTPValidationError{value: val, isSet: true}
-}
-
-func (v NullableHTTPValidationError) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableHTTPValidationError) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the IncludeOptions type satisfies the MappedNullable interface at compile time
@@ -168,11 +167,7 @@ func (o *IncludeOptions) UnsetSourceFacts() {
 }
 
 func (o IncludeOptions) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o IncludeOptions) ToMap() (map[string]interface{}, error) {
@@ -189,40 +184,10 @@ func (o IncludeOptions) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableIncludeOptions struct {
-	value *IncludeOptions
-	isSet bool
-}
-
-func (v NullableIncludeOptions) Get() *IncludeOptions {
-	return v.value
-}
-
-func (v *NullableIncludeOptions) Set(val *IncludeOptions) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableIncludeOptions) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableIncludeOptions) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableIncludeOptions = Nullable[IncludeOptions]
 
 func NewNullableIncludeOptions(val *IncludeOptions) *NullableIncludeOptions {
-	return &NullableIncludeOptions{value: val, isSet: true}
-}
-
-func (v NullableIncludeOptions) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableIncludeOptions) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
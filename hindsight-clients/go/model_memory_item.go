@@ -14,7 +14,6 @@ import (
 	"encoding/json"
 	"time"
 	"bytes"
-	"fmt"
 )
 
 // checks if the MemoryItem type satisfies the MappedNullable interface at compile time
@@ -344,11 +343,7 @@ func (o *MemoryItem) UnsetObservationScopes() {
 }
 
 func (o MemoryItem) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o MemoryItem) ToMap() (map[string]interface{}, error) {
@@ -380,24 +375,14 @@ func (o MemoryItem) ToMap() (map[string]interface{}, error) {
 
 func (o *MemoryItem) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"content",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varMemoryItem := _MemoryItem{}
@@ -415,40 +400,10 @@ func (o *MemoryItem) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableMemoryItem struct {
-	value *MemoryItem
-	isSet bool
-}
-
-func (v NullableMemoryItem) Get() *MemoryItem {
-	return v.value
-}
-
-func (v *NullableMemoryItem) Set(val *MemoryItem) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableMemoryItem) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableMemoryItem) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableMemoryItem = Nullable[MemoryItem]
 
 func NewNullableMemoryItem(val *MemoryItem) *NullableMemoryItem {
-	return &NullableMemoryItem{value: val, isSet: true}
-}
-
-func (v NullableMemoryItem) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableMemoryItem) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
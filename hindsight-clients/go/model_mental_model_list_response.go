@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the MentalModelListResponse type satisfies the MappedNullable interface at compile time
@@ -69,11 +68,7 @@ func (o *MentalModelListResponse) SetItems(v []MentalModelResponse) {
 }
 
 func (o MentalModelListResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o MentalModelListResponse) ToMap() (map[string]interface{}, error) {
@@ -84,24 +79,14 @@ func (o MentalModelListResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *MentalModelListResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"items",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varMentalModelListResponse := _MentalModelListResponse{}
@@ -119,40 +104,10 @@ func (o *MentalModelListResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableMentalModelListResponse struct {
-	value *MentalModelListResponse
-	isSet bool
-}
-
-func (v NullableMentalModelListResponse) Get() *MentalModelListResponse {
-	return v.value
-}
-
-func (v *NullableMentalModelListResponse) Set(val *MentalModelListResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableMentalModelListResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableMentalModelListResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableMentalModelListResponse = Nullable[MentalModelListResponse]
 
 func NewNullableMentalModelListResponse(val *MentalModelListResponse) *NullableMentalModelListResponse {
-	return &NullableMentalModelListResponse{value: val, isSet: true}
-}
-
-func (v NullableMentalModelListResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableMentalModelListResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
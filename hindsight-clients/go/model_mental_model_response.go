@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the MentalModelResponse type satisfies the MappedNullable interface at compile time
@@ -397,11 +396,7 @@ func (o *MentalModelResponse) SetReflectResponse(v map[string]interface{}) {
 }
 
 func (o MentalModelResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o MentalModelResponse) ToMap() (map[string]interface{}, error) {
@@ -434,8 +429,7 @@ func (o MentalModelResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *MentalModelResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"id",
 		"bank_id",
@@ -444,18 +438,9 @@ func (o *MentalModelResponse) UnmarshalJSON(data []byte) (err error) {
 		"content",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varMentalModelResponse := _MentalModelResponse{}
@@ -473,40 +458,10 @@ func (o *MentalModelResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableMentalModelResponse struct {
-	value *MentalModelResponse
-	isSet bool
-}
-
-func (v NullableMentalModelResponse) Get() *MentalModelResponse {
-	return v.value
-}
-
-func (v *NullableMentalModelResponse) Set(val *MentalModelResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableMentalModelResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableMentalModelResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableMentalModelResponse = Nullable[MentalModelResponse]
 
 func NewNullableMentalModelResponse(val *MentalModelResponse) *NullableMentalModelResponse {
-	return &NullableMentalModelResponse{value: val, isSet: true}
-}
-
-func (v NullableMentalModelResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableMentalModelResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the MentalModelTrigger type satisfies the MappedNullable interface at compile time
@@ -77,11 +76,7 @@ func (o *MentalModelTrigger) SetRefreshAfterConsolidation(v bool) {
 }
 
 func (o MentalModelTrigger) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o MentalModelTrigger) ToMap() (map[string]interface{}, error) {
@@ -92,40 +87,10 @@ func (o MentalModelTrigger) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableMentalModelTrigger struct {
-	value *MentalModelTrigger
-	isSet bool
-}
-
-func (v NullableMentalModelTrigger) Get() *MentalModelTrigger {
-	return v.value
-}
-
-func (v *NullableMentalModelTrigger) Set(val *MentalModelTrigger) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableMentalModelTrigger) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableMentalModelTrigger) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableMentalModelTrigger = Nullable[MentalModelTrigger]
 
 func NewNullableMentalModelTrigger(val *MentalModelTrigger) *NullableMentalModelTrigger {
-	return &NullableMentalModelTrigger{value: val, isSet: true}
-}
-
-func (v NullableMentalModelTrigger) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableMentalModelTrigger) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
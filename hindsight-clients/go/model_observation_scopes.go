@@ -73,40 +73,10 @@ func (src *ObservationScopes) MarshalJSON() ([]byte, error) {
 }
 
 
-type NullableObservationScopes struct {
-	value *ObservationScopes
-	isSet bool
-}
-
-func (v NullableObservationScopes) Get() *ObservationScopes {
-	return v.value
-}
-
-func (v *NullableObservationScopes) Set(val *ObservationScopes) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableObservationScopes) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableObservationScopes) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableObservationScopes = Nullable[ObservationScopes]
 
 func NewNullableObservationScopes(val *ObservationScopes) *NullableObservationScopes {
-	return &NullableObservationScopes{value: val, isSet: true}
-}
-
-func (v NullableObservationScopes) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableObservationScopes) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
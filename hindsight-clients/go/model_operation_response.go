@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the OperationResponse type satisfies the MappedNullable interface at compile time
@@ -26,7 +25,7 @@ type OperationResponse struct {
 	ItemsCount int32 `json:"items_count"`
 	DocumentId NullableString `json:"document_id,omitempty"`
 	CreatedAt string `json:"created_at"`
-	Status string `json:"status"`
+	Status OperationStatus `json:"status"`
 	ErrorMessage NullableString `json:"error_message"`
 }
 
@@ -36,7 +35,7 @@ type _OperationResponse OperationResponse
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewOperationResponse(id string, taskType string, itemsCount int32, createdAt string, status string, errorMessage NullableString) *OperationResponse {
+func NewOperationResponse(id string, taskType string, itemsCount int32, createdAt string, status OperationStatus, errorMessage NullableString) *OperationResponse {
 	this := OperationResponse{}
 	this.Id = id
 	this.TaskType = taskType
@@ -194,9 +193,9 @@ func (o *OperationResponse) SetCreatedAt(v string) {
 }
 
 // GetStatus returns the Status field value
-func (o *OperationResponse) GetStatus() string {
+func (o *OperationResponse) GetStatus() OperationStatus {
 	if o == nil {
-		var ret string
+		var ret OperationStatus
 		return ret
 	}
 
@@ -205,7 +204,7 @@ func (o *OperationResponse) GetStatus() string {
 
 // GetStatusOk returns a tuple with the Status field value
 // and a boolean to check if the value has been set.
-func (o *OperationResponse) GetStatusOk() (*string, bool) {
+func (o *OperationResponse) GetStatusOk() (*OperationStatus, bool) {
 	if o == nil {
 		return nil, false
 	}
@@ -213,7 +212,7 @@ func (o *OperationResponse) GetStatusOk() (*string, bool) {
 }
 
 // SetStatus sets field value
-func (o *OperationResponse) SetStatus(v string) {
+func (o *OperationResponse) SetStatus(v OperationStatus) {
 	o.Status = v
 }
 
@@ -244,11 +243,7 @@ func (o *OperationResponse) SetErrorMessage(v string) {
 }
 
 func (o OperationResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o OperationResponse) ToMap() (map[string]interface{}, error) {
@@ -267,8 +262,7 @@ func (o OperationResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *OperationResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"id",
 		"task_type",
@@ -278,18 +272,9 @@ func (o *OperationResponse) UnmarshalJSON(data []byte) (err error) {
 		"error_message",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varOperationResponse := _OperationResponse{}
@@ -307,40 +292,10 @@ func (o *OperationResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableOperationResponse struct {
-	value *OperationResponse
-	isSet bool
-}
-
-func (v NullableOperationResponse) Get() *OperationResponse {
-	return v.value
-}
-
-func (v *NullableOperationResponse) Set(val *OperationResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableOperationResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableOperationResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableOperationResponse = Nullable[OperationResponse]
 
 func NewNullableOperationResponse(val *OperationResponse) *NullableOperationResponse {
-	return &NullableOperationResponse{value: val, isSet: true}
-}
-
-func (v NullableOperationResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableOperationResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
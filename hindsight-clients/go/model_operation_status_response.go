@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the OperationStatusResponse type satisfies the MappedNullable interface at compile time
@@ -22,7 +21,7 @@ var _ MappedNullable = &OperationStatusResponse{}
 // OperationStatusResponse Response model for getting a single operation status.
 type OperationStatusResponse struct {
 	OperationId string `json:"operation_id"`
-	Status string `json:"status"`
+	Status OperationStatus `json:"status"`
 	OperationType NullableString `json:"operation_type,omitempty"`
 	CreatedAt NullableString `json:"created_at,omitempty"`
 	UpdatedAt NullableString `json:"updated_at,omitempty"`
@@ -38,7 +37,7 @@ type _OperationStatusResponse OperationStatusResponse
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewOperationStatusResponse(operationId string, status string) *OperationStatusResponse {
+func NewOperationStatusResponse(operationId string, status OperationStatus) *OperationStatusResponse {
 	this := OperationStatusResponse{}
 	this.OperationId = operationId
 	this.Status = status
@@ -78,9 +77,9 @@ func (o *OperationStatusResponse) SetOperationId(v string) {
 }
 
 // GetStatus returns the Status field value
-func (o *OperationStatusResponse) GetStatus() string {
+func (o *OperationStatusResponse) GetStatus() OperationStatus {
 	if o == nil {
-		var ret string
+		var ret OperationStatus
 		return ret
 	}
 
@@ -89,7 +88,7 @@ func (o *OperationStatusResponse) GetStatus() string {
 
 // GetStatusOk returns a tuple with the Status field value
 // and a boolean to check if the value has been set.
-func (o *OperationStatusResponse) GetStatusOk() (*string, bool) {
+func (o *OperationStatusResponse) GetStatusOk() (*OperationStatus, bool) {
 	if o == nil {
 		return nil, false
 	}
@@ -97,7 +96,7 @@ func (o *OperationStatusResponse) GetStatusOk() (*string, bool) {
 }
 
 // SetStatus sets field value
-func (o *OperationStatusResponse) SetStatus(v string) {
+func (o *OperationStatusResponse) SetStatus(v OperationStatus) {
 	o.Status = v
 }
 
@@ -378,11 +377,7 @@ func (o *OperationStatusResponse) SetChildOperations(v []ChildOperationStatus) {
 }
 
 func (o OperationStatusResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o OperationStatusResponse) ToMap() (map[string]interface{}, error) {
@@ -415,25 +410,15 @@ func (o OperationStatusResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *OperationStatusResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"operation_id",
 		"status",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varOperationStatusResponse := _OperationStatusResponse{}
@@ -451,40 +436,10 @@ func (o *OperationStatusResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableOperationStatusResponse struct {
-	value *OperationStatusResponse
-	isSet bool
-}
-
-func (v NullableOperationStatusResponse) Get() *OperationStatusResponse {
-	return v.value
-}
-
-func (v *NullableOperationStatusResponse) Set(val *OperationStatusResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableOperationStatusResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableOperationStatusResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableOperationStatusResponse = Nullable[OperationStatusResponse]
 
 func NewNullableOperationStatusResponse(val *OperationStatusResponse) *NullableOperationStatusResponse {
-	return &NullableOperationStatusResponse{value: val, isSet: true}
-}
-
-func (v NullableOperationStatusResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableOperationStatusResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
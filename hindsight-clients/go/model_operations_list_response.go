@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the OperationsListResponse type satisfies the MappedNullable interface at compile time
@@ -173,11 +172,7 @@ func (o *OperationsListResponse) SetOperations(v []OperationResponse) {
 }
 
 func (o OperationsListResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o OperationsListResponse) ToMap() (map[string]interface{}, error) {
@@ -192,8 +187,7 @@ func (o OperationsListResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *OperationsListResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"bank_id",
 		"total",
@@ -202,18 +196,9 @@ func (o *OperationsListResponse) UnmarshalJSON(data []byte) (err error) {
 		"operations",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varOperationsListResponse := _OperationsListResponse{}
@@ -231,40 +216,10 @@ func (o *OperationsListResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableOperationsListResponse struct {
-	value *OperationsListResponse
-	isSet bool
-}
-
-func (v NullableOperationsListResponse) Get() *OperationsListResponse {
-	return v.value
-}
-
-func (v *NullableOperationsListResponse) Set(val *OperationsListResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableOperationsListResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableOperationsListResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableOperationsListResponse = Nullable[OperationsListResponse]
 
 func NewNullableOperationsListResponse(val *OperationsListResponse) *NullableOperationsListResponse {
-	return &NullableOperationsListResponse{value: val, isSet: true}
-}
-
-func (v NullableOperationsListResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableOperationsListResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the RecallRequest type satisfies the MappedNullable interface at compile time
@@ -22,7 +21,7 @@ var _ MappedNullable = &RecallRequest{}
 // RecallRequest Request model for recall endpoint.
 type RecallRequest struct {
 	Query string `json:"query"`
-	Types []string `json:"types,omitempty"`
+	Types []FactType `json:"types,omitempty"`
 	Budget *Budget `json:"budget,omitempty"`
 	MaxTokens *int32 `json:"max_tokens,omitempty"`
 	Trace *bool `json:"trace,omitempty"`
@@ -31,7 +30,7 @@ type RecallRequest struct {
 	Include *IncludeOptions `json:"include,omitempty"`
 	Tags []string `json:"tags,omitempty"`
 	// How to match tags: 'any' (OR, includes untagged), 'all' (AND, includes untagged), 'any_strict' (OR, excludes untagged), 'all_strict' (AND, excludes untagged).
-	TagsMatch *string `json:"tags_match,omitempty"`
+	TagsMatch *TagsMatch `json:"tags_match,omitempty"`
 }
 
 type _RecallRequest RecallRequest
@@ -47,7 +46,7 @@ func NewRecallRequest(query string) *RecallRequest {
 	this.MaxTokens = &maxTokens
 	var trace bool = false
 	this.Trace = &trace
-	var tagsMatch string = "any"
+	var tagsMatch TagsMatch = "any"
 	this.TagsMatch = &tagsMatch
 	return &this
 }
@@ -61,7 +60,7 @@ func NewRecallRequestWithDefaults() *RecallRequest {
 	this.MaxTokens = &maxTokens
 	var trace bool = false
 	this.Trace = &trace
-	var tagsMatch string = "any"
+	var tagsMatch TagsMatch = "any"
 	this.TagsMatch = &tagsMatch
 	return &this
 }
@@ -91,9 +90,9 @@ func (o *RecallRequest) SetQuery(v string) {
 }
 
 // GetTypes returns the Types field value if set, zero value otherwise (both if not set or set to explicit null).
-func (o *RecallRequest) GetTypes() []string {
+func (o *RecallRequest) GetTypes() []FactType {
 	if o == nil {
-		var ret []string
+		var ret []FactType
 		return ret
 	}
 	return o.Types
@@ -102,7 +101,7 @@ func (o *RecallRequest) GetTypes() []string {
 // GetTypesOk returns a tuple with the Types field value if set, nil otherwise
 // and a boolean to check if the value has been set.
 // NOTE: If the value is an explicit nil, `nil, true` will be returned
-func (o *RecallRequest) GetTypesOk() ([]string, bool) {
+func (o *RecallRequest) GetTypesOk() ([]FactType, bool) {
 	if o == nil || IsNil(o.Types) {
 		return nil, false
 	}
@@ -118,8 +117,8 @@ func (o *RecallRequest) HasTypes() bool {
 	return false
 }
 
-// SetTypes gets a reference to the given []string and assigns it to the Types field.
-func (o *RecallRequest) SetTypes(v []string) {
+// SetTypes gets a reference to the given []FactType and assigns it to the Types field.
+func (o *RecallRequest) SetTypes(v []FactType) {
 	o.Types = v
 }
 
@@ -327,9 +326,9 @@ func (o *RecallRequest) SetTags(v []string) {
 }
 
 // GetTagsMatch returns the TagsMatch field value if set, zero value otherwise.
-func (o *RecallRequest) GetTagsMatch() string {
+func (o *RecallRequest) GetTagsMatch() TagsMatch {
 	if o == nil || IsNil(o.TagsMatch) {
-		var ret string
+		var ret TagsMatch
 		return ret
 	}
 	return *o.TagsMatch
@@ -337,7 +336,7 @@ func (o *RecallRequest) GetTagsMatch() string {
 
 // GetTagsMatchOk returns a tuple with the TagsMatch field value if set, nil otherwise
 // and a boolean to check if the value has been set.
-func (o *RecallRequest) GetTagsMatchOk() (*string, bool) {
+func (o *RecallRequest) GetTagsMatchOk() (*TagsMatch, bool) {
 	if o == nil || IsNil(o.TagsMatch) {
 		return nil, false
 	}
@@ -353,17 +352,13 @@ func (o *RecallRequest) HasTagsMatch() bool {
 	return false
 }
 
-// SetTagsMatch gets a reference to the given string and assigns it to the TagsMatch field.
-func (o *RecallRequest) SetTagsMatch(v string) {
+// SetTagsMatch gets a reference to the given TagsMatch and assigns it to the TagsMatch field.
+func (o *RecallRequest) SetTagsMatch(v TagsMatch) {
 	o.TagsMatch = &v
 }
 
 func (o RecallRequest) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o RecallRequest) ToMap() (map[string]interface{}, error) {
@@ -398,24 +393,14 @@ func (o RecallRequest) ToMap() (map[string]interface{}, error) {
 
 func (o *RecallRequest) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"query",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varRecallRequest := _RecallRequest{}
@@ -433,40 +418,10 @@ func (o *RecallRequest) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableRecallRequest struct {
-	value *RecallRequest
-	isSet bool
-}
-
-func (v NullableRecallRequest) Get() *RecallRequest {
-	return v.value
-}
-
-func (v *NullableRecallRequest) Set(val *RecallRequest) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableRecallRequest) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableRecallRequest) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableRecallRequest = Nullable[RecallRequest]
 
 func NewNullableRecallRequest(val *RecallRequest) *NullableRecallRequest {
-	return &NullableRecallRequest{value: val, isSet: true}
-}
-
-func (v NullableRecallRequest) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableRecallRequest) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
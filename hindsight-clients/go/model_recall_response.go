@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the RecallResponse type satisfies the MappedNullable interface at compile time
@@ -205,11 +204,7 @@ func (o *RecallResponse) SetSourceFacts(v map[string]RecallResult) {
 }
 
 func (o RecallResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o RecallResponse) ToMap() (map[string]interface{}, error) {
@@ -232,24 +227,14 @@ func (o RecallResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *RecallResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"results",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varRecallResponse := _RecallResponse{}
@@ -267,40 +252,10 @@ func (o *RecallResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableRecallResponse struct {
-	value *RecallResponse
-	isSet bool
-}
-
-func (v NullableRecallResponse) Get() *RecallResponse {
-	return v.value
-}
-
-func (v *NullableRecallResponse) Set(val *RecallResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableRecallResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableRecallResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableRecallResponse = Nullable[RecallResponse]
 
 func NewNullableRecallResponse(val *RecallResponse) *NullableRecallResponse {
-	return &NullableRecallResponse{value: val, isSet: true}
-}
-
-func (v NullableRecallResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableRecallResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the RecallResult type satisfies the MappedNullable interface at compile time
@@ -23,7 +22,7 @@ var _ MappedNullable = &RecallResult{}
 type RecallResult struct {
 	Id string `json:"id"`
 	Text string `json:"text"`
-	Type NullableString `json:"type,omitempty"`
+	Type NullableFactType `json:"type,omitempty"`
 	Entities []string `json:"entities,omitempty"`
 	Context NullableString `json:"context,omitempty"`
 	OccurredStart NullableString `json:"occurred_start,omitempty"`
@@ -106,9 +105,9 @@ func (o *RecallResult) SetText(v string) {
 }
 
 // GetType returns the Type field value if set, zero value otherwise (both if not set or set to explicit null).
-func (o *RecallResult) GetType() string {
+func (o *RecallResult) GetType() FactType {
 	if o == nil || IsNil(o.Type.Get()) {
-		var ret string
+		var ret FactType
 		return ret
 	}
 	return *o.Type.Get()
@@ -117,7 +116,7 @@ func (o *RecallResult) GetType() string {
 // GetTypeOk returns a tuple with the Type field value if set, nil otherwise
 // and a boolean to check if the value has been set.
 // NOTE: If the value is an explicit nil, `nil, true` will be returned
-func (o *RecallResult) GetTypeOk() (*string, bool) {
+func (o *RecallResult) GetTypeOk() (*FactType, bool) {
 	if o == nil {
 		return nil, false
 	}
@@ -133,8 +132,8 @@ func (o *RecallResult) HasType() bool {
 	return false
 }
 
-// SetType gets a reference to the given NullableString and assigns it to the Type field.
-func (o *RecallResult) SetType(v string) {
+// SetType gets a reference to the given NullableFactType and assigns it to the Type field.
+func (o *RecallResult) SetType(v FactType) {
 	o.Type.Set(&v)
 }
 // SetTypeNil sets the value for Type to be an explicit nil
@@ -532,11 +531,7 @@ func (o *RecallResult) SetSourceFactIds(v []string) {
 }
 
 func (o RecallResult) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o RecallResult) ToMap() (map[string]interface{}, error) {
@@ -581,25 +576,15 @@ func (o RecallResult) ToMap() (map[string]interface{}, error) {
 
 func (o *RecallResult) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"id",
 		"text",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varRecallResult := _RecallResult{}
@@ -617,40 +602,10 @@ func (o *RecallResult) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableRecallResult struct {
-	value *RecallResult
-	isSet bool
-}
-
-func (v NullableRecallResult) Get() *RecallResult {
-	return v.value
-}
-
-func (v *NullableRecallResult) Set(val *RecallResult) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableRecallResult) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableRecallResult) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableRecallResult = Nullable[RecallResult]
 
 func NewNullableRecallResult(val *RecallResult) *NullableRecallResult {
-	return &NullableRecallResult{value: val, isSet: true}
-}
-
-func (v NullableRecallResult) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableRecallResult) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
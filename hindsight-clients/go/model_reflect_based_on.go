@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the ReflectBasedOn type satisfies the MappedNullable interface at compile time
@@ -141,11 +140,7 @@ func (o *ReflectBasedOn) SetDirectives(v []ReflectDirective) {
 }
 
 func (o ReflectBasedOn) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ReflectBasedOn) ToMap() (map[string]interface{}, error) {
@@ -162,40 +157,10 @@ func (o ReflectBasedOn) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableReflectBasedOn struct {
-	value *ReflectBasedOn
-	isSet bool
-}
-
-func (v NullableReflectBasedOn) Get() *ReflectBasedOn {
-	return v.value
-}
-
-func (v *NullableReflectBasedOn) Set(val *ReflectBasedOn) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableReflectBasedOn) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableReflectBasedOn) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableReflectBasedOn = Nullable[ReflectBasedOn]
 
 func NewNullableReflectBasedOn(val *ReflectBasedOn) *NullableReflectBasedOn {
-	return &NullableReflectBasedOn{value: val, isSet: true}
-}
-
-func (v NullableReflectBasedOn) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableReflectBasedOn) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ReflectDirective type satisfies the MappedNullable interface at compile time
@@ -124,11 +123,7 @@ func (o *ReflectDirective) SetContent(v string) {
 }
 
 func (o ReflectDirective) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ReflectDirective) ToMap() (map[string]interface{}, error) {
@@ -141,26 +136,16 @@ func (o ReflectDirective) ToMap() (map[string]interface{}, error) {
 
 func (o *ReflectDirective) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"id",
 		"name",
 		"content",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varReflectDirective := _ReflectDirective{}
@@ -178,40 +163,10 @@ func (o *ReflectDirective) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableReflectDirective struct {
-	value *ReflectDirective
-	isSet bool
-}
-
-func (v NullableReflectDirective) Get() *ReflectDirective {
-	return v.value
-}
-
-func (v *NullableReflectDirective) Set(val *ReflectDirective) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableReflectDirective) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableReflectDirective) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableReflectDirective = Nullable[ReflectDirective]
 
 func NewNullableReflectDirective(val *ReflectDirective) *NullableReflectDirective {
-	return &NullableReflectDirective{value: val, isSet: true}
-}
-
-func (v NullableReflectDirective) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableReflectDirective) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
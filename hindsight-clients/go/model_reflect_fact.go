@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ReflectFact type satisfies the MappedNullable interface at compile time
@@ -285,11 +284,7 @@ func (o *ReflectFact) UnsetOccurredEnd() {
 }
 
 func (o ReflectFact) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ReflectFact) ToMap() (map[string]interface{}, error) {
@@ -315,24 +310,14 @@ func (o ReflectFact) ToMap() (map[string]interface{}, error) {
 
 func (o *ReflectFact) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"text",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varReflectFact := _ReflectFact{}
@@ -350,40 +335,10 @@ func (o *ReflectFact) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableReflectFact struct {
-	value *ReflectFact
-	isSet bool
-}
-
-func (v NullableReflectFact) Get() *ReflectFact {
-	return v.value
-}
-
-func (v *NullableReflectFact) Set(val *ReflectFact) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableReflectFact) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableReflectFact) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableReflectFact = Nullable[ReflectFact]
 
 func NewNullableReflectFact(val *ReflectFact) *NullableReflectFact {
-	return &NullableReflectFact{value: val, isSet: true}
-}
-
-func (v NullableReflectFact) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableReflectFact) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
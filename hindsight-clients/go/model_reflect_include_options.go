@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the ReflectIncludeOptions type satisfies the MappedNullable interface at compile time
@@ -116,11 +115,7 @@ func (o *ReflectIncludeOptions) UnsetToolCalls() {
 }
 
 func (o ReflectIncludeOptions) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ReflectIncludeOptions) ToMap() (map[string]interface{}, error) {
@@ -134,40 +129,10 @@ func (o ReflectIncludeOptions) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableReflectIncludeOptions struct {
-	value *ReflectIncludeOptions
-	isSet bool
-}
-
-func (v NullableReflectIncludeOptions) Get() *ReflectIncludeOptions {
-	return v.value
-}
-
-func (v *NullableReflectIncludeOptions) Set(val *ReflectIncludeOptions) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableReflectIncludeOptions) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableReflectIncludeOptions) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableReflectIncludeOptions = Nullable[ReflectIncludeOptions]
 
 func NewNullableReflectIncludeOptions(val *ReflectIncludeOptions) *NullableReflectIncludeOptions {
-	return &NullableReflectIncludeOptions{value: val, isSet: true}
-}
-
-func (v NullableReflectIncludeOptions) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableReflectIncludeOptions) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
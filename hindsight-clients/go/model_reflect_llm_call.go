@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ReflectLLMCall type satisfies the MappedNullable interface at compile time
@@ -97,11 +96,7 @@ func (o *ReflectLLMCall) SetDurationMs(v int32) {
 }
 
 func (o ReflectLLMCall) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ReflectLLMCall) ToMap() (map[string]interface{}, error) {
@@ -113,25 +108,15 @@ func (o ReflectLLMCall) ToMap() (map[string]interface{}, error) {
 
 func (o *ReflectLLMCall) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"scope",
 		"duration_ms",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varReflectLLMCall := _ReflectLLMCall{}
@@ -149,40 +134,10 @@ func (o *ReflectLLMCall) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableReflectLLMCall struct {
-	value *ReflectLLMCall
-	isSet bool
-}
-
-func (v NullableReflectLLMCall) Get() *ReflectLLMCall {
-	return v.value
-}
-
-func (v *NullableReflectLLMCall) Set(val *ReflectLLMCall) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableReflectLLMCall) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableReflectLLMCall) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableReflectLLMCall = Nullable[ReflectLLMCall]
 
 func NewNullableReflectLLMCall(val *ReflectLLMCall) *NullableReflectLLMCall {
-	return &NullableReflectLLMCall{value: val, isSet: true}
-}
-
-func (v NullableReflectLLMCall) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableReflectLLMCall) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
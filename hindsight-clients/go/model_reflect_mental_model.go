@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ReflectMentalModel type satisfies the MappedNullable interface at compile time
@@ -140,11 +139,7 @@ func (o *ReflectMentalModel) UnsetContext() {
 }
 
 func (o ReflectMentalModel) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ReflectMentalModel) ToMap() (map[string]interface{}, error) {
@@ -159,25 +154,15 @@ func (o ReflectMentalModel) ToMap() (map[string]interface{}, error) {
 
 func (o *ReflectMentalModel) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"id",
 		"text",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varReflectMentalModel := _ReflectMentalModel{}
@@ -195,40 +180,10 @@ func (o *ReflectMentalModel) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableReflectMentalModel struct {
-	value *ReflectMentalModel
-	isSet bool
-}
-
-func (v NullableReflectMentalModel) Get() *ReflectMentalModel {
-	return v.value
-}
-
-func (v *NullableReflectMentalModel) Set(val *ReflectMentalModel) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableReflectMentalModel) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableReflectMentalModel) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableReflectMentalModel = Nullable[ReflectMentalModel]
 
 func NewNullableReflectMentalModel(val *ReflectMentalModel) *NullableReflectMentalModel {
-	return &NullableReflectMentalModel{value: val, isSet: true}
-}
-
-func (v NullableReflectMentalModel) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableReflectMentalModel) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ReflectRequest type satisfies the MappedNullable interface at compile time
@@ -31,7 +30,7 @@ type ReflectRequest struct {
 	ResponseSchema map[string]interface{} `json:"response_schema,omitempty"`
 	Tags []string `json:"tags,omitempty"`
 	// How to match tags: 'any' (OR, includes untagged), 'all' (AND, includes untagged), 'any_strict' (OR, excludes untagged), 'all_strict' (AND, excludes untagged).
-	TagsMatch *string `json:"tags_match,omitempty"`
+	TagsMatch *TagsMatch `json:"tags_match,omitempty"`
 }
 
 type _ReflectRequest ReflectRequest
@@ -45,7 +44,7 @@ func NewReflectRequest(query string) *ReflectRequest {
 	this.Query = query
 	var maxTokens int32 = 4096
 	this.MaxTokens = &maxTokens
-	var tagsMatch string = "any"
+	var tagsMatch TagsMatch = "any"
 	this.TagsMatch = &tagsMatch
 	return &this
 }
@@ -57,7 +56,7 @@ func NewReflectRequestWithDefaults() *ReflectRequest {
 	this := ReflectRequest{}
 	var maxTokens int32 = 4096
 	this.MaxTokens = &maxTokens
-	var tagsMatch string = "any"
+	var tagsMatch TagsMatch = "any"
 	this.TagsMatch = &tagsMatch
 	return &this
 }
@@ -291,9 +290,9 @@ func (o *ReflectRequest) SetTags(v []string) {
 }
 
 // GetTagsMatch returns the TagsMatch field value if set, zero value otherwise.
-func (o *ReflectRequest) GetTagsMatch() string {
+func (o *ReflectRequest) GetTagsMatch() TagsMatch {
 	if o == nil || IsNil(o.TagsMatch) {
-		var ret string
+		var ret TagsMatch
 		return ret
 	}
 	return *o.TagsMatch
@@ -301,7 +300,7 @@ func (o *ReflectRequest) GetTagsMatch() string {
 
 // GetTagsMatchOk returns a tuple with the TagsMatch field value if set, nil otherwise
 // and a boolean to check if the value has been set.
-func (o *ReflectRequest) GetTagsMatchOk() (*string, bool) {
+func (o *ReflectRequest) GetTagsMatchOk() (*TagsMatch, bool) {
 	if o == nil || IsNil(o.TagsMatch) {
 		return nil, false
 	}
@@ -317,17 +316,13 @@ func (o *ReflectRequest) HasTagsMatch() bool {
 	return false
 }
 
-// SetTagsMatch gets a reference to the given string and assigns it to the TagsMatch field.
-func (o *ReflectRequest) SetTagsMatch(v string) {
+// SetTagsMatch gets a reference to the given TagsMatch and assigns it to the TagsMatch field.
+func (o *ReflectRequest) SetTagsMatch(v TagsMatch) {
 	o.TagsMatch = &v
 }
 
 func (o ReflectRequest) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ReflectRequest) ToMap() (map[string]interface{}, error) {
@@ -359,24 +354,14 @@ func (o ReflectRequest) ToMap() (map[string]interface{}, error) {
 
 func (o *ReflectRequest) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"query",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varReflectRequest := _ReflectRequest{}
@@ -394,40 +379,10 @@ func (o *ReflectRequest) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableReflectRequest struct {
-	value *ReflectRequest
-	isSet bool
-}
-
-func (v NullableReflectRequest) Get() *ReflectRequest {
-	return v.value
-}
-
-func (v *NullableReflectRequest) Set(val *ReflectRequest) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableReflectRequest) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableReflectRequest) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableReflectRequest = Nullable[ReflectRequest]
 
 func NewNullableReflectRequest(val *ReflectRequest) *NullableReflectRequest {
-	return &NullableReflectRequest{value: val, isSet: true}
-}
-
-func (v NullableReflectRequest) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableReflectRequest) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
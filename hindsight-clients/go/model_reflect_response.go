@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ReflectResponse type satisfies the MappedNullable interface at compile time
@@ -233,11 +232,7 @@ func (o *ReflectResponse) UnsetTrace() {
 }
 
 func (o ReflectResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ReflectResponse) ToMap() (map[string]interface{}, error) {
@@ -260,24 +255,14 @@ func (o ReflectResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *ReflectResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"text",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varReflectResponse := _ReflectResponse{}
@@ -295,40 +280,10 @@ func (o *ReflectResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableReflectResponse struct {
-	value *ReflectResponse
-	isSet bool
-}
-
-func (v NullableReflectResponse) Get() *ReflectResponse {
-	return v.value
-}
-
-func (v *NullableReflectResponse) Set(val *ReflectResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableReflectResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableReflectResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableReflectResponse = Nullable[ReflectResponse]
 
 func NewNullableReflectResponse(val *ReflectResponse) *NullableReflectResponse {
-	return &NullableReflectResponse{value: val, isSet: true}
-}
-
-func (v NullableReflectResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableReflectResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ReflectToolCall type satisfies the MappedNullable interface at compile time
@@ -196,11 +195,7 @@ func (o *ReflectToolCall) SetIteration(v int32) {
 }
 
 func (o ReflectToolCall) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ReflectToolCall) ToMap() (map[string]interface{}, error) {
@@ -219,26 +214,16 @@ func (o ReflectToolCall) ToMap() (map[string]interface{}, error) {
 
 func (o *ReflectToolCall) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"tool",
 		"input",
 		"duration_ms",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varReflectToolCall := _ReflectToolCall{}
@@ -256,40 +241,10 @@ func (o *ReflectToolCall) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableReflectToolCall struct {
-	value *ReflectToolCall
-	isSet bool
-}
-
-func (v NullableReflectToolCall) Get() *ReflectToolCall {
-	return v.value
-}
-
-func (v *NullableReflectToolCall) Set(val *ReflectToolCall) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableReflectToolCall) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableReflectToolCall) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableReflectToolCall = Nullable[ReflectToolCall]
 
 func NewNullableReflectToolCall(val *ReflectToolCall) *NullableReflectToolCall {
-	return &NullableReflectToolCall{value: val, isSet: true}
-}
-
-func (v NullableReflectToolCall) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableReflectToolCall) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the ReflectTrace type satisfies the MappedNullable interface at compile time
@@ -107,11 +106,7 @@ func (o *ReflectTrace) SetLlmCalls(v []ReflectLLMCall) {
 }
 
 func (o ReflectTrace) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ReflectTrace) ToMap() (map[string]interface{}, error) {
@@ -125,40 +120,10 @@ func (o ReflectTrace) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableReflectTrace struct {
-	value *ReflectTrace
-	isSet bool
-}
-
-func (v NullableReflectTrace) Get() *ReflectTrace {
-	return v.value
-}
-
-func (v *NullableReflectTrace) Set(val *ReflectTrace) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableReflectTrace) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableReflectTrace) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableReflectTrace = Nullable[ReflectTrace]
 
 func NewNullableReflectTrace(val *ReflectTrace) *NullableReflectTrace {
-	return &NullableReflectTrace{value: val, isSet: true}
-}
-
-func (v NullableReflectTrace) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableReflectTrace) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the RetainRequest type satisfies the MappedNullable interface at compile time
@@ -141,11 +140,7 @@ func (o *RetainRequest) SetDocumentTags(v []string) {
 }
 
 func (o RetainRequest) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o RetainRequest) ToMap() (map[string]interface{}, error) {
@@ -162,24 +157,14 @@ func (o RetainRequest) ToMap() (map[string]interface{}, error) {
 
 func (o *RetainRequest) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"items",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varRetainRequest := _RetainRequest{}
@@ -197,40 +182,10 @@ func (o *RetainRequest) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableRetainRequest struct {
-	value *RetainRequest
-	isSet bool
-}
-
-func (v NullableRetainRequest) Get() *RetainRequest {
-	return v.value
-}
-
-func (v *NullableRetainRequest) Set(val *RetainRequest) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableRetainRequest) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableRetainRequest) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableRetainRequest = Nullable[RetainRequest]
 
 func NewNullableRetainRequest(val *RetainRequest) *NullableRetainRequest {
-	return &NullableRetainRequest{value: val, isSet: true}
-}
-
-func (v NullableRetainRequest) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableRetainRequest) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
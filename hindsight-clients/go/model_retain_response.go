@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the RetainResponse type satisfies the MappedNullable interface at compile time
@@ -234,11 +233,7 @@ func (o *RetainResponse) UnsetUsage() {
 }
 
 func (o RetainResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o RetainResponse) ToMap() (map[string]interface{}, error) {
@@ -258,8 +253,7 @@ func (o RetainResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *RetainResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"success",
 		"bank_id",
@@ -267,18 +261,9 @@ func (o *RetainResponse) UnmarshalJSON(data []byte) (err error) {
 		"async",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varRetainResponse := _RetainResponse{}
@@ -296,40 +281,10 @@ func (o *RetainResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableRetainResponse struct {
-	value *RetainResponse
-	isSet bool
-}
-
-func (v NullableRetainResponse) Get() *RetainResponse {
-	return v.value
-}
-
-func (v *NullableRetainResponse) Set(val *RetainResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableRetainResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableRetainResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableRetainResponse = Nullable[RetainResponse]
 
 func NewNullableRetainResponse(val *RetainResponse) *NullableRetainResponse {
-	return &NullableRetainResponse{value: val, isSet: true}
-}
-
-func (v NullableRetainResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableRetainResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the SourceFactsIncludeOptions type satisfies the MappedNullable interface at compile time
@@ -77,11 +76,7 @@ func (o *SourceFactsIncludeOptions) SetMaxTokens(v int32) {
 }
 
 func (o SourceFactsIncludeOptions) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o SourceFactsIncludeOptions) ToMap() (map[string]interface{}, error) {
@@ -92,40 +87,10 @@ func (o SourceFactsIncludeOptions) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableSourceFactsIncludeOptions struct {
-	value *SourceFactsIncludeOptions
-	isSet bool
-}
-
-func (v NullableSourceFactsIncludeOptions) Get() *SourceFactsIncludeOptions {
-	return v.value
-}
-
-func (v *NullableSourceFactsIncludeOptions) Set(val *SourceFactsIncludeOptions) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableSourceFactsIncludeOptions) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableSourceFactsIncludeOptions) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableSourceFactsIncludeOptions = Nullable[SourceFactsIncludeOptions]
 
 func NewNullableSourceFactsIncludeOptions(val *SourceFactsIncludeOptions) *NullableSourceFactsIncludeOptions {
-	return &NullableSourceFactsIncludeOptions{value: val, isSet: true}
-}
-
-func (v NullableSourceFactsIncludeOptions) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableSourceFactsIncludeOptions) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
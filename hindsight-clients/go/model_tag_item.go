@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the TagItem type satisfies the MappedNullable interface at compile time
@@ -97,11 +96,7 @@ func (o *TagItem) SetCount(v int32) {
 }
 
 func (o TagItem) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o TagItem) ToMap() (map[string]interface{}, error) {
@@ -113,25 +108,15 @@ func (o TagItem) ToMap() (map[string]interface{}, error) {
 
 func (o *TagItem) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"tag",
 		"count",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varTagItem := _TagItem{}
@@ -149,40 +134,10 @@ func (o *TagItem) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableTagItem struct {
-	value *TagItem
-	isSet bool
-}
-
-func (v NullableTagItem) Get() *TagItem {
-	return v.value
-}
-
-func (v *NullableTagItem) Set(val *TagItem) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableTagItem) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableTagItem) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableTagItem = Nullable[TagItem]
 
 func NewNullableTagItem(val *TagItem) *NullableTagItem {
-	return &NullableTagItem{value: val, isSet: true}
-}
-
-func (v NullableTagItem) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableTagItem) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
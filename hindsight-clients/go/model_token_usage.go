@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the TokenUsage type satisfies the MappedNullable interface at compile time
@@ -153,11 +152,7 @@ func (o *TokenUsage) SetTotalTokens(v int32) {
 }
 
 func (o TokenUsage) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o TokenUsage) ToMap() (map[string]interface{}, error) {
@@ -174,40 +169,10 @@ func (o TokenUsage) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableTokenUsage struct {
-	value *TokenUsage
-	isSet bool
-}
-
-func (v NullableTokenUsage) Get() *TokenUsage {
-	return v.value
-}
-
-func (v *NullableTokenUsage) Set(val *TokenUsage) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableTokenUsage) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableTokenUsage) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableTokenUsage = Nullable[TokenUsage]
 
 func NewNullableTokenUsage(val *TokenUsage) *NullableTokenUsage {
-	return &NullableTokenUsage{value: val, isSet: true}
-}
-
-func (v NullableTokenUsage) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableTokenUsage) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the ToolCallsIncludeOptions type satisfies the MappedNullable interface at compile time
@@ -77,11 +76,7 @@ func (o *ToolCallsIncludeOptions) SetOutput(v bool) {
 }
 
 func (o ToolCallsIncludeOptions) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ToolCallsIncludeOptions) ToMap() (map[string]interface{}, error) {
@@ -92,40 +87,10 @@ func (o ToolCallsIncludeOptions) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableToolCallsIncludeOptions struct {
-	value *ToolCallsIncludeOptions
-	isSet bool
-}
-
-func (v NullableToolCallsIncludeOptions) Get() *ToolCallsIncludeOptions {
-	return v.value
-}
-
-func (v *NullableToolCallsIncludeOptions) Set(val *ToolCallsIncludeOptions) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableToolCallsIncludeOptions) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableToolCallsIncludeOptions) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableToolCallsIncludeOptions = Nullable[ToolCallsIncludeOptions]
 
 func NewNullableToolCallsIncludeOptions(val *ToolCallsIncludeOptions) *NullableToolCallsIncludeOptions {
-	return &NullableToolCallsIncludeOptions{value: val, isSet: true}
-}
-
-func (v NullableToolCallsIncludeOptions) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableToolCallsIncludeOptions) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
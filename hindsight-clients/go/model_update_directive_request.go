@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the UpdateDirectiveRequest type satisfies the MappedNullable interface at compile time
@@ -245,11 +244,7 @@ func (o *UpdateDirectiveRequest) SetTags(v []string) {
 }
 
 func (o UpdateDirectiveRequest) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o UpdateDirectiveRequest) ToMap() (map[string]interface{}, error) {
@@ -272,40 +267,10 @@ func (o UpdateDirectiveRequest) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableUpdateDirectiveRequest struct {
-	value *UpdateDirectiveRequest
-	isSet bool
-}
-
-func (v NullableUpdateDirectiveRequest) Get() *UpdateDirectiveRequest {
-	return v.value
-}
-
-func (v *NullableUpdateDirectiveRequest) Set(val *UpdateDirectiveRequest) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableUpdateDirectiveRequest) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableUpdateDirectiveRequest) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableUpdateDirectiveRequest = Nullable[UpdateDirectiveRequest]
 
 func NewNullableUpdateDirectiveRequest(val *UpdateDirectiveRequest) *NullableUpdateDirectiveRequest {
-	return &NullableUpdateDirectiveRequest{value: val, isSet: true}
-}
-
-func (v NullableUpdateDirectiveRequest) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableUpdateDirectiveRequest) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the UpdateDispositionRequest type satisfies the MappedNullable interface at compile time
@@ -69,11 +68,7 @@ func (o *UpdateDispositionRequest) SetDisposition(v DispositionTraits) {
 }
 
 func (o UpdateDispositionRequest) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o UpdateDispositionRequest) ToMap() (map[string]interface{}, error) {
@@ -84,24 +79,14 @@ func (o UpdateDispositionRequest) ToMap() (map[string]interface{}, error) {
 
 func (o *UpdateDispositionRequest) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"disposition",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varUpdateDispositionRequest := _UpdateDispositionRequest{}
@@ -119,40 +104,10 @@ func (o *UpdateDispositionRequest) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableUpdateDispositionRequest struct {
-	value *UpdateDispositionRequest
-	isSet bool
-}
-
-func (v NullableUpdateDispositionRequest) Get() *UpdateDispositionRequest {
-	return v.value
-}
-
-func (v *NullableUpdateDispositionRequest) Set(val *UpdateDispositionRequest) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableUpdateDispositionRequest) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableUpdateDispositionRequest) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableUpdateDispositionRequest = Nullable[UpdateDispositionRequest]
 
 func NewNullableUpdateDispositionRequest(val *UpdateDispositionRequest) *NullableUpdateDispositionRequest {
-	return &NullableUpdateDispositionRequest{value: val, isSet: true}
-}
-
-func (v NullableUpdateDispositionRequest) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableUpdateDispositionRequest) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
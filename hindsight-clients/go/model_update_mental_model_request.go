@@ -11,7 +11,6 @@ API version: 0.4.14
 package hindsight
 
 import (
-	"encoding/json"
 )
 
 // checks if the UpdateMentalModelRequest type satisfies the MappedNullable interface at compile time
@@ -245,11 +244,7 @@ func (o *UpdateMentalModelRequest) UnsetTrigger() {
 }
 
 func (o UpdateMentalModelRequest) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o UpdateMentalModelRequest) ToMap() (map[string]interface{}, error) {
@@ -272,40 +267,10 @@ func (o UpdateMentalModelRequest) ToMap() (map[string]interface{}, error) {
 	return toSerialize, nil
 }
 
-type NullableUpdateMentalModelRequest struct {
-	value *UpdateMentalModelRequest
-	isSet bool
-}
-
-func (v NullableUpdateMentalModelRequest) Get() *UpdateMentalModelRequest {
-	return v.value
-}
-
-func (v *NullableUpdateMentalModelRequest) Set(val *UpdateMentalModelRequest) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableUpdateMentalModelRequest) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableUpdateMentalModelRequest) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableUpdateMentalModelRequest = Nullable[UpdateMentalModelRequest]
 
 func NewNullableUpdateMentalModelRequest(val *UpdateMentalModelRequest) *NullableUpdateMentalModelRequest {
-	return &NullableUpdateMentalModelRequest{value: val, isSet: true}
-}
-
-func (v NullableUpdateMentalModelRequest) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableUpdateMentalModelRequest) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
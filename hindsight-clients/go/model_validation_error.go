@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the ValidationError type satisfies the MappedNullable interface at compile time
@@ -121,11 +120,7 @@ func (o *ValidationError) SetType(v string) {
 }
 
 func (o ValidationError) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o ValidationError) ToMap() (map[string]interface{}, error) {
@@ -138,26 +133,16 @@ func (o ValidationError) ToMap() (map[string]interface{}, error) {
 
 func (o *ValidationError) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"loc",
 		"msg",
 		"type",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varValidationError := _ValidationError{}
@@ -175,40 +160,10 @@ func (o *ValidationError) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableValidationError struct {
-	value *ValidationError
-	isSet bool
-}
-
-func (v NullableValidationError) Get() *ValidationError {
-	return v.value
-}
-
-func (v *NullableValidationError) Set(val *ValidationError) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableValidationError) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableValidationError) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableValidationError = Nullable[ValidationError]
 
 func NewNullableValidationError(val *ValidationError) *NullableValidationError {
-	return &NullableValidationError{value: val, isSet: true}
-}
-
-func (v NullableValidationError) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableValidationError) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -68,40 +68,10 @@ func (src *ValidationErrorLocInner) MarshalJSON() ([]byte, error) {
 }
 
 
-type NullableValidationErrorLocInner struct {
-	value *ValidationErrorLocInner
-	isSet bool
-}
-
-func (v NullableValidationErrorLocInner) Get() *ValidationErrorLocInner {
-	return v.value
-}
-
-func (v *NullableValidationErrorLocInner) Set(val *ValidationErrorLocInner) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableValidationErrorLocInner) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableValidationErrorLocInner) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableValidationErrorLocInner = Nullable[ValidationErrorLocInner]
 
 func NewNullableValidationErrorLocInner(val *ValidationErrorLocInner) *NullableValidationErrorLocInner {
-	return &NullableValidationErrorLocInner{value: val, isSet: true}
-}
-
-func (v NullableValidationErrorLocInner) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableValidationErrorLocInner) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
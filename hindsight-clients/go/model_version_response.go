@@ -13,7 +13,6 @@ package hindsight
 import (
 	"encoding/json"
 	"bytes"
-	"fmt"
 )
 
 // checks if the VersionResponse type satisfies the MappedNullable interface at compile time
@@ -97,11 +96,7 @@ func (o *VersionResponse) SetFeatures(v FeaturesInfo) {
 }
 
 func (o VersionResponse) MarshalJSON() ([]byte, error) {
-	toSerialize,err := o.ToMap()
-	if err != nil {
-		return []byte{}, err
-	}
-	return json.Marshal(toSerialize)
+	return marshalModel(o)
 }
 
 func (o VersionResponse) ToMap() (map[string]interface{}, error) {
@@ -113,25 +108,15 @@ func (o VersionResponse) ToMap() (map[string]interface{}, error) {
 
 func (o *VersionResponse) UnmarshalJSON(data []byte) (err error) {
 	// This validates that all required properties are included in the JSON object
-	// by unmarshalling the object into a generic map with string keys and checking
-	// that every required field exists as a key in the generic map.
+	// by scanning its top-level keys.
 	requiredProperties := []string{
 		"api_version",
 		"features",
 	}
 
-	allProperties := make(map[string]interface{})
-
-	err = json.Unmarshal(data, &allProperties)
-
+	err = checkRequiredProperties(data, requiredProperties)
 	if err != nil {
-		return err;
-	}
-
-	for _, requiredProperty := range(requiredProperties) {
-		if _, exists := allProperties[requiredProperty]; !exists {
-			return fmt.Errorf("no value given for required property %v", requiredProperty)
-		}
+		return err
 	}
 
 	varVersionResponse := _VersionResponse{}
@@ -149,40 +134,10 @@ func (o *VersionResponse) UnmarshalJSON(data []byte) (err error) {
 	return err
 }
 
-type NullableVersionResponse struct {
-	value *VersionResponse
-	isSet bool
-}
-
-func (v NullableVersionResponse) Get() *VersionResponse {
-	return v.value
-}
-
-func (v *NullableVersionResponse) Set(val *VersionResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableVersionResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableVersionResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableVersionResponse = Nullable[VersionResponse]
 
 func NewNullableVersionResponse(val *VersionResponse) *NullableVersionResponse {
-	return &NullableVersionResponse{value: val, isSet: true}
-}
-
-func (v NullableVersionResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableVersionResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 
@@ -0,0 +1,210 @@
+package hindsight
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Nullable holds a JSON value with three states: unset (the field is omitted
+// when encoding), null (the field is encoded as null) and set to a value.
+// The distinction matters for PATCH requests, where omitting a field leaves
+// it unchanged and null clears it.
+//
+// The generated NullableString, NullableTime, NullableTokenUsage and similar
+// types are aliases of Nullable instantiations, so the helpers below work on
+// every nullable model field.
+//
+// Example:
+//
+//	item := hindsight.NewMemoryItem("Alice prefers tea")
+//	item.Timestamp = hindsight.NullableOf(time.Now())
+//	item.Context = hindsight.Null[string]()
+type Nullable[T any] struct {
+	value *T
+	isSet bool
+}
+
+// NewNullable returns a set Nullable holding val; a nil val is null.
+func NewNullable[T any](val *T) *Nullable[T] {
+	return &Nullable[T]{value: val, isSet: true}
+}
+
+// NullableOf returns a Nullable set to v.
+func NullableOf[T any](v T) Nullable[T] {
+	return Nullable[T]{value: &v, isSet: true}
+}
+
+// Null returns a Nullable that is explicitly null.
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{isSet: true}
+}
+
+// Get returns the value, or nil if the Nullable is unset or null.
+func (v Nullable[T]) Get() *T {
+	return v.value
+}
+
+// Set sets the value; a nil val makes the Nullable null.
+func (v *Nullable[T]) Set(val *T) {
+	v.value = val
+	v.isSet = true
+}
+
+// SetValue sets the value to val.
+func (v *Nullable[T]) SetValue(val T) {
+	v.Set(&val)
+}
+
+// SetNull makes the Nullable explicitly null.
+func (v *Nullable[T]) SetNull() {
+	v.Set(nil)
+}
+
+// IsSet reports whether the Nullable is null or holds a value.
+func (v Nullable[T]) IsSet() bool {
+	return v.isSet
+}
+
+// IsNull reports whether the Nullable is explicitly null.
+func (v Nullable[T]) IsNull() bool {
+	return v.isSet && v.value == nil
+}
+
+// Unset returns the Nullable to the unset state.
+func (v *Nullable[T]) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+// Value returns the value and whether one is present.
+func (v Nullable[T]) Value() (T, bool) {
+	if v.value == nil {
+		var zero T
+		return zero, false
+	}
+	return *v.value, true
+}
+
+// ValueOr returns the value, or def if the Nullable is unset or null.
+func (v Nullable[T]) ValueOr(def T) T {
+	if v.value == nil {
+		return def
+	}
+	return *v.value
+}
+
+// MarshalJSON encodes the value, or null when unset or null. Models omit
+// unset fields before this is reached.
+func (v Nullable[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+// nullableValue gives the model encoder in codec.go access to the value of
+// any Nullable instantiation.
+type nullableValue interface {
+	jsonValue() interface{}
+}
+
+func (v Nullable[T]) jsonValue() interface{} {
+	return v.value
+}
+
+// UnmarshalJSON decodes a value or null and marks the Nullable as set.
+func (v *Nullable[T]) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
+
+// Optional holds a value that may be absent but is never null, for fields
+// the API does not allow to be null. The generated models keep pointers for
+// such fields; Optional is used by hand-written types such as BankConfig,
+// and Ptr and OptionalFromPtr convert to and from the model pointers.
+//
+// Because an unset Optional has no JSON encoding, a struct holding one must
+// leave it out when it is unset, as BankConfig does; encoding/json's
+// omitempty does not apply to struct types.
+type Optional[T any] struct {
+	value T
+	isSet bool
+}
+
+// Some returns an Optional set to v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, isSet: true}
+}
+
+// OptionalFromPtr returns an Optional set to *p, or an unset Optional if p
+// is nil.
+func OptionalFromPtr[T any](p *T) Optional[T] {
+	if p == nil {
+		return Optional[T]{}
+	}
+	return Some(*p)
+}
+
+// Get returns the value and whether it is set.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.isSet
+}
+
+// IsSet reports whether the Optional holds a value.
+func (o Optional[T]) IsSet() bool {
+	return o.isSet
+}
+
+// Set sets the value to v.
+func (o *Optional[T]) Set(v T) {
+	o.value = v
+	o.isSet = true
+}
+
+// Unset clears the value.
+func (o *Optional[T]) Unset() {
+	var zero T
+	o.value = zero
+	o.isSet = false
+}
+
+// ValueOr returns the value, or def if it is unset.
+func (o Optional[T]) ValueOr(def T) T {
+	if !o.isSet {
+		return def
+	}
+	return o.value
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if it is unset.
+func (o Optional[T]) Ptr() *T {
+	if !o.isSet {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+// ErrOptionalUnset is returned when encoding an unset Optional, which would
+// otherwise send a null the API does not accept.
+var ErrOptionalUnset = errors.New("hindsight: cannot encode an unset Optional")
+
+// MarshalJSON encodes the value. It returns ErrOptionalUnset if the value is
+// unset.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.isSet {
+		return nil, ErrOptionalUnset
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value. A JSON null leaves the Optional unset.
+func (o *Optional[T]) UnmarshalJSON(src []byte) error {
+	if bytes.Equal(bytes.TrimSpace(src), []byte("null")) {
+		o.Unset()
+		return nil
+	}
+	if err := json.Unmarshal(src, &o.value); err != nil {
+		return err
+	}
+	o.isSet = true
+	return nil
+}
@@ -0,0 +1,70 @@
+package hindsight
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNullableStatesInModels(t *testing.T) {
+	update := CreateBankRequest{}
+	b, _ := json.Marshal(update)
+	if string(b) != `{}` {
+		t.Fatalf("unset fields must be omitted, got %s", b)
+	}
+
+	update.Mission = Null[string]()
+	update.Name = NullableOf("Support bot")
+	b, _ = json.Marshal(update)
+	if string(b) != `{"mission":null,"name":"Support bot"}` {
+		t.Fatalf("got %s", b)
+	}
+
+	var decoded CreateBankRequest
+	if err := json.Unmarshal([]byte(`{"mission":null}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Mission.IsNull() || decoded.Name.IsSet() {
+		t.Fatalf("mission null=%v, name set=%v", decoded.Mission.IsNull(), decoded.Name.IsSet())
+	}
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	item := NewMemoryItem("Alice prefers tea")
+	item.Timestamp = NullableOf(ts)
+	if got, ok := item.Timestamp.Value(); !ok || !got.Equal(ts) {
+		t.Fatalf("Value() = %v, %v", got, ok)
+	}
+	if item.Context.ValueOr("none") != "none" {
+		t.Fatal("ValueOr should return the default for an unset field")
+	}
+}
+
+func TestOptional(t *testing.T) {
+	var o Optional[int32]
+	if o.Ptr() != nil || o.IsSet() {
+		t.Fatal("zero Optional must be unset")
+	}
+	if _, err := json.Marshal(o); !errors.Is(err, ErrOptionalUnset) {
+		t.Fatalf("marshalling an unset Optional: %v", err)
+	}
+	if _, err := json.Marshal(struct{ N Optional[int32] }{}); !errors.Is(err, ErrOptionalUnset) {
+		t.Fatalf("marshalling an unset Optional field: %v", err)
+	}
+	b, _ := json.Marshal(Some(int32(5)))
+	if string(b) != "5" {
+		t.Fatalf("got %s", b)
+	}
+	if err := json.Unmarshal([]byte("null"), &o); err != nil || o.IsSet() {
+		t.Fatalf("null should leave the Optional unset: %v", err)
+	}
+	if err := json.Unmarshal([]byte("7"), &o); err != nil || *o.Ptr() != 7 {
+		t.Fatalf("got %v, %v", o, err)
+	}
+
+	req := NewRecallRequest("q")
+	req.MaxTokens = Some(int32(100)).Ptr()
+	if OptionalFromPtr(req.MaxTokens).ValueOr(0) != 100 {
+		t.Fatal("round trip through a pointer field failed")
+	}
+}
@@ -0,0 +1,166 @@
+package hindsight
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OperationClass groups API operations by their cost profile on the server.
+// Retain, recall and reflect are LLM- or embedding-backed and degrade
+// independently of the cheap CRUD endpoints, so client-side policies can be
+// scoped to them.
+type OperationClass string
+
+const (
+	OperationClassRetain  OperationClass = "retain"
+	OperationClassRecall  OperationClass = "recall"
+	OperationClassReflect OperationClass = "reflect"
+	OperationClassOther   OperationClass = "other"
+)
+
+// Operation identifies the API operation behind an outgoing HTTP request.
+type Operation struct {
+	// Name is the operation identifier used by Configuration.OperationServers,
+	// for example "MemoryAPIService.RecallMemories".
+	Name string
+	// Tenant is the namespace segment of the request path ("default" unless
+	// tenant routing is in use), or empty for operations outside /v1.
+	Tenant string
+	// BankID is the bank the request targets, or empty for bank-less operations.
+	BankID string
+	// Class is the cost class of the operation.
+	Class OperationClass
+}
+
+type operationRoute struct {
+	method   string
+	name     string
+	segments []string
+	class    OperationClass
+}
+
+// operationRoutes mirrors the paths in the generated api_*.go files, with the
+// hard-coded "default" namespace segment replaced by {tenant}. Literal routes
+// must come before parameterised routes sharing the same prefix.
+var operationRoutes = []operationRoute{
+	route(http.MethodPost, "BanksAPIService.AddBankBackground", "/v1/{tenant}/banks/{bank_id}/background", OperationClassOther),
+	route(http.MethodDelete, "BanksAPIService.ClearObservations", "/v1/{tenant}/banks/{bank_id}/observations", OperationClassOther),
+	route(http.MethodPut, "BanksAPIService.CreateOrUpdateBank", "/v1/{tenant}/banks/{bank_id}", OperationClassOther),
+	route(http.MethodDelete, "BanksAPIService.DeleteBank", "/v1/{tenant}/banks/{bank_id}", OperationClassOther),
+	route(http.MethodGet, "BanksAPIService.GetAgentStats", "/v1/{tenant}/banks/{bank_id}/stats", OperationClassOther),
+	route(http.MethodGet, "BanksAPIService.GetBankConfig", "/v1/{tenant}/banks/{bank_id}/config", OperationClassOther),
+	route(http.MethodGet, "BanksAPIService.GetBankProfile", "/v1/{tenant}/banks/{bank_id}/profile", OperationClassOther),
+	route(http.MethodGet, "BanksAPIService.ListBanks", "/v1/{tenant}/banks", OperationClassOther),
+	route(http.MethodDelete, "BanksAPIService.ResetBankConfig", "/v1/{tenant}/banks/{bank_id}/config", OperationClassOther),
+	route(http.MethodPost, "BanksAPIService.TriggerConsolidation", "/v1/{tenant}/banks/{bank_id}/consolidate", OperationClassReflect),
+	route(http.MethodPatch, "BanksAPIService.UpdateBank", "/v1/{tenant}/banks/{bank_id}", OperationClassOther),
+	route(http.MethodPatch, "BanksAPIService.UpdateBankConfig", "/v1/{tenant}/banks/{bank_id}/config", OperationClassOther),
+	route(http.MethodPut, "BanksAPIService.UpdateBankDisposition", "/v1/{tenant}/banks/{bank_id}/profile", OperationClassOther),
+	route(http.MethodPost, "DirectivesAPIService.CreateDirective", "/v1/{tenant}/banks/{bank_id}/directives", OperationClassOther),
+	route(http.MethodDelete, "DirectivesAPIService.DeleteDirective", "/v1/{tenant}/banks/{bank_id}/directives/{directive_id}", OperationClassOther),
+	route(http.MethodGet, "DirectivesAPIService.GetDirective", "/v1/{tenant}/banks/{bank_id}/directives/{directive_id}", OperationClassOther),
+	route(http.MethodGet, "DirectivesAPIService.ListDirectives", "/v1/{tenant}/banks/{bank_id}/directives", OperationClassOther),
+	route(http.MethodPatch, "DirectivesAPIService.UpdateDirective", "/v1/{tenant}/banks/{bank_id}/directives/{directive_id}", OperationClassOther),
+	route(http.MethodDelete, "DocumentsAPIService.DeleteDocument", "/v1/{tenant}/banks/{bank_id}/documents/{document_id}", OperationClassOther),
+	route(http.MethodGet, "DocumentsAPIService.GetChunk", "/v1/{tenant}/chunks/{chunk_id}", OperationClassOther),
+	route(http.MethodGet, "DocumentsAPIService.GetDocument", "/v1/{tenant}/banks/{bank_id}/documents/{document_id}", OperationClassOther),
+	route(http.MethodGet, "DocumentsAPIService.ListDocuments", "/v1/{tenant}/banks/{bank_id}/documents", OperationClassOther),
+	route(http.MethodGet, "EntitiesAPIService.GetEntity", "/v1/{tenant}/banks/{bank_id}/entities/{entity_id}", OperationClassOther),
+	route(http.MethodGet, "EntitiesAPIService.ListEntities", "/v1/{tenant}/banks/{bank_id}/entities", OperationClassOther),
+	route(http.MethodPost, "EntitiesAPIService.RegenerateEntityObservations", "/v1/{tenant}/banks/{bank_id}/entities/{entity_id}/regenerate", OperationClassReflect),
+	route(http.MethodPost, "FilesAPIService.FileRetain", "/v1/{tenant}/banks/{bank_id}/files/retain", OperationClassRetain),
+	route(http.MethodDelete, "MemoryAPIService.ClearBankMemories", "/v1/{tenant}/banks/{bank_id}/memories", OperationClassOther),
+	route(http.MethodDelete, "MemoryAPIService.ClearMemoryObservations", "/v1/{tenant}/banks/{bank_id}/memories/{memory_id}/observations", OperationClassOther),
+	route(http.MethodGet, "MemoryAPIService.GetGraph", "/v1/{tenant}/banks/{bank_id}/graph", OperationClassOther),
+	route(http.MethodGet, "MemoryAPIService.ListMemories", "/v1/{tenant}/banks/{bank_id}/memories/list", OperationClassOther),
+	route(http.MethodGet, "MemoryAPIService.GetMemory", "/v1/{tenant}/banks/{bank_id}/memories/{memory_id}", OperationClassOther),
+	route(http.MethodGet, "MemoryAPIService.ListTags", "/v1/{tenant}/banks/{bank_id}/tags", OperationClassOther),
+	route(http.MethodPost, "MemoryAPIService.RecallMemories", "/v1/{tenant}/banks/{bank_id}/memories/recall", OperationClassRecall),
+	route(http.MethodPost, "MemoryAPIService.Reflect", "/v1/{tenant}/banks/{bank_id}/reflect", OperationClassReflect),
+	route(http.MethodPost, "MemoryAPIService.RetainMemories", "/v1/{tenant}/banks/{bank_id}/memories", OperationClassRetain),
+	route(http.MethodPost, "MentalModelsAPIService.CreateMentalModel", "/v1/{tenant}/banks/{bank_id}/mental-models", OperationClassReflect),
+	route(http.MethodDelete, "MentalModelsAPIService.DeleteMentalModel", "/v1/{tenant}/banks/{bank_id}/mental-models/{mental_model_id}", OperationClassOther),
+	route(http.MethodGet, "MentalModelsAPIService.GetMentalModel", "/v1/{tenant}/banks/{bank_id}/mental-models/{mental_model_id}", OperationClassOther),
+	route(http.MethodGet, "MentalModelsAPIService.ListMentalModels", "/v1/{tenant}/banks/{bank_id}/mental-models", OperationClassOther),
+	route(http.MethodPost, "MentalModelsAPIService.RefreshMentalModel", "/v1/{tenant}/banks/{bank_id}/mental-models/{mental_model_id}/refresh", OperationClassReflect),
+	route(http.MethodPatch, "MentalModelsAPIService.UpdateMentalModel", "/v1/{tenant}/banks/{bank_id}/mental-models/{mental_model_id}", OperationClassOther),
+	route(http.MethodGet, "MonitoringAPIService.GetVersion", "/version", OperationClassOther),
+	route(http.MethodGet, "MonitoringAPIService.HealthEndpointHealthGet", "/health", OperationClassOther),
+	route(http.MethodGet, "MonitoringAPIService.MetricsEndpointMetricsGet", "/metrics", OperationClassOther),
+	route(http.MethodDelete, "OperationsAPIService.CancelOperation", "/v1/{tenant}/banks/{bank_id}/operations/{operation_id}", OperationClassOther),
+	route(http.MethodGet, "OperationsAPIService.GetOperationStatus", "/v1/{tenant}/banks/{bank_id}/operations/{operation_id}", OperationClassOther),
+	route(http.MethodGet, "OperationsAPIService.ListOperations", "/v1/{tenant}/banks/{bank_id}/operations", OperationClassOther),
+}
+
+func route(method, name, path string, class OperationClass) operationRoute {
+	return operationRoute{
+		method:   method,
+		name:     name,
+		segments: strings.Split(strings.Trim(path, "/"), "/"),
+		class:    class,
+	}
+}
+
+// match reports whether the trailing segments of path match the route. The
+// server URL may carry its own base path, so only the suffix is compared.
+// On success, offset is the index in segments where the route starts.
+func (r operationRoute) match(method string, segments []string) (op Operation, offset int, ok bool) {
+	if method != r.method || len(segments) < len(r.segments) {
+		return Operation{}, 0, false
+	}
+	offset = len(segments) - len(r.segments)
+	op = Operation{Name: r.name, Class: r.class}
+	for i, want := range r.segments {
+		got := segments[offset+i]
+		if strings.HasPrefix(want, "{") {
+			if got == "" {
+				return Operation{}, 0, false
+			}
+			switch want {
+			case "{tenant}":
+				op.Tenant = unescapePathSegment(got)
+			case "{bank_id}":
+				op.BankID = unescapePathSegment(got)
+			}
+			continue
+		}
+		if got != want {
+			return Operation{}, 0, false
+		}
+	}
+	return op, offset, true
+}
+
+func unescapePathSegment(s string) string {
+	if unescaped, err := url.PathUnescape(s); err == nil {
+		return unescaped
+	}
+	return s
+}
+
+func splitPath(req *http.Request) []string {
+	return strings.Split(strings.Trim(req.URL.EscapedPath(), "/"), "/")
+}
+
+// matchRoute returns the route matching req along with the parsed operation
+// and the offset of the route within the request path segments.
+func matchRoute(req *http.Request, segments []string) (operationRoute, Operation, int, bool) {
+	for _, r := range operationRoutes {
+		if op, offset, ok := r.match(req.Method, segments); ok {
+			return r, op, offset, true
+		}
+	}
+	return operationRoute{}, Operation{}, 0, false
+}
+
+// OperationForRequest identifies the API operation an outgoing request was
+// built for. It is intended for use inside custom transports and middleware.
+// Requests that do not match a known route yield an Operation with an empty
+// Name and OperationClassOther.
+func OperationForRequest(req *http.Request) Operation {
+	if _, op, _, ok := matchRoute(req, splitPath(req)); ok {
+		return op
+	}
+	return Operation{Class: OperationClassOther}
+}
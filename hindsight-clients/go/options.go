@@ -0,0 +1,367 @@
+package hindsight
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the server URL used when neither the environment nor the
+// CLI config file names one.
+const DefaultAPIURL = "http://localhost:8888"
+
+// ConfigSource records where LoadCLIConfig found the server URL.
+type ConfigSource int
+
+const (
+	ConfigSourceDefault ConfigSource = iota
+	ConfigSourceEnvironment
+	ConfigSourceFile
+	// ConfigSourceOption means the URL was passed to New with WithBaseURL.
+	ConfigSourceOption
+	// ConfigSourceProfile means the URL is the hindsight-embed daemon of
+	// the profile passed to New with WithProfile.
+	ConfigSourceProfile
+)
+
+func (s ConfigSource) String() string {
+	switch s {
+	case ConfigSourceDefault:
+		return "default"
+	case ConfigSourceEnvironment:
+		return "environment variable"
+	case ConfigSourceFile:
+		return "config file"
+	case ConfigSourceOption:
+		return "option"
+	case ConfigSourceProfile:
+		return "hindsight-embed profile"
+	}
+	return fmt.Sprintf("ConfigSource(%d)", int(s))
+}
+
+// CLIConfig is the server URL and API key the hindsight CLI would use.
+type CLIConfig struct {
+	APIURL string
+	APIKey string
+	Source ConfigSource
+	// Path is the config file that was read, if any.
+	Path string
+}
+
+// LoadCLIConfig resolves the server the same way as the hindsight CLI:
+//
+//  1. HINDSIGHT_API_URL (and HINDSIGHT_API_KEY) from the environment
+//  2. api_url and api_key from ~/.hindsight/config
+//  3. DefaultAPIURL
+//
+// HINDSIGHT_API_KEY overrides the file's api_key. As in the CLI, the URL
+// must start with http:// or https://, and a variable that is set counts
+// even when empty: an empty HINDSIGHT_API_URL is rejected and an empty
+// HINDSIGHT_API_KEY clears the file's api_key.
+func LoadCLIConfig() (*CLIConfig, error) {
+	path := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		path = filepath.Join(home, ".hindsight", "config")
+	}
+	return loadCLIConfig(path)
+}
+
+func loadCLIConfig(path string) (*CLIConfig, error) {
+	envKey, hasEnvKey := os.LookupEnv("HINDSIGHT_API_KEY")
+	if url, ok := os.LookupEnv("HINDSIGHT_API_URL"); ok {
+		return newCLIConfig(url, envKey, ConfigSourceEnvironment, "")
+	}
+
+	if path != "" {
+		url, key, err := readCLIConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if url != "" {
+			if hasEnvKey {
+				key = envKey
+			}
+			return newCLIConfig(url, key, ConfigSourceFile, path)
+		}
+	}
+	return newCLIConfig(DefaultAPIURL, envKey, ConfigSourceDefault, "")
+}
+
+func newCLIConfig(url, key string, source ConfigSource, path string) (*CLIConfig, error) {
+	if err := validateAPIURL(url); err != nil {
+		return nil, err
+	}
+	return &CLIConfig{APIURL: url, APIKey: key, Source: source, Path: path}, nil
+}
+
+// validateAPIURL applies the CLI's check to a configured URL.
+func validateAPIURL(url string) error {
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		return nil
+	}
+	return fmt.Errorf("hindsight: invalid API URL %q: must start with http:// or https://", url)
+}
+
+// readCLIConfigFile reads api_url and api_key exactly as the CLI's
+// Config::load_from_file does: the last non-empty value of any line
+// starting with the key wins, whatever section it is in, and the value is
+// the text between the first and second "=" with surrounding double, then
+// single, quotes removed. A missing file yields no values.
+func readCLIConfigFile(path string) (url, key string, err error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("hindsight: reading config file: %w", err)
+	}
+	defer f.Close()
+
+	value := func(line string) string {
+		parts := strings.Split(line, "=")
+		if len(parts) < 2 {
+			return ""
+		}
+		return strings.Trim(strings.Trim(strings.TrimSpace(parts[1]), `"`), "'")
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "api_url"):
+			if v := value(line); v != "" {
+				url = v
+			}
+		case strings.HasPrefix(line, "api_key"):
+			if v := value(line); v != "" {
+				key = v
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", "", fmt.Errorf("hindsight: reading config file: %w", err)
+	}
+	return url, key, nil
+}
+
+// Option configures a client built by New.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	baseURL    string
+	apiKey     *string
+	profile    string
+	configFile string
+	timeout    time.Duration
+	userAgent  string
+	transport  http.RoundTripper
+	retry      *RetryPolicy
+	maxBody    int64
+	compress   *CompressionConfig
+	cache      *ResponseCache
+	hedger     *Hedger
+	timeouts   *OperationTimeouts
+	validate   bool
+	coalesce   bool
+	middleware []Middleware
+}
+
+// WithBaseURL sets the server URL, overriding the environment and the CLI
+// config file. Besides http:// and https://, it accepts unix:// URLs (see
+// UnixSocketMiddleware), which the CLI does not.
+func WithBaseURL(url string) Option {
+	return func(o *clientOptions) { o.baseURL = url }
+}
+
+// WithAPIKey sets the API key, overriding HINDSIGHT_API_KEY and the config
+// file. An empty key sends no Authorization header.
+func WithAPIKey(key string) Option {
+	return func(o *clientOptions) { o.apiKey = &key }
+}
+
+// WithProfile connects to the hindsight-embed daemon of the named profile,
+// found with DiscoverDaemon, instead of the server in the environment or the
+// CLI config file. The API key is taken from WithAPIKey or
+// HINDSIGHT_API_KEY.
+func WithProfile(name string) Option {
+	return func(o *clientOptions) { o.profile = name }
+}
+
+// WithConfigFile reads the given file instead of ~/.hindsight/config.
+func WithConfigFile(path string) Option {
+	return func(o *clientOptions) { o.configFile = path }
+}
+
+// WithTimeout bounds each call, including retries. Defaults to no timeout.
+// Use WithOperationTimeouts to give slow operations such as reflect more time
+// than cheap ones.
+func WithTimeout(d time.Duration) Option {
+	return func(o *clientOptions) { o.timeout = d }
+}
+
+// WithOperationTimeouts bounds calls whose context has no deadline by the
+// timeout of their operation; see TimeoutMiddleware.
+func WithOperationTimeouts(timeouts OperationTimeouts) Option {
+	return func(o *clientOptions) { o.timeouts = &timeouts }
+}
+
+// WithUserAgent sets the User-Agent header.
+func WithUserAgent(ua string) Option {
+	return func(o *clientOptions) { o.userAgent = ua }
+}
+
+// WithTransport sets the underlying HTTP transport. Defaults to
+// http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *clientOptions) { o.transport = rt }
+}
+
+// WithRetries retries idempotent requests up to n times with the default
+// backoff. Use WithRetryPolicy for finer control.
+func WithRetries(n int) Option {
+	return func(o *clientOptions) { o.retry = &RetryPolicy{MaxRetries: n} }
+}
+
+// WithRetryPolicy installs RetryMiddleware with the given policy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) { o.retry = &policy }
+}
+
+// WithMaxResponseSize fails calls whose response body exceeds n bytes with a
+// *ResponseTooLargeError; see MaxResponseSizeMiddleware.
+func WithMaxResponseSize(n int64) Option {
+	return func(o *clientOptions) { o.maxBody = n }
+}
+
+// WithCompression gzips large request bodies and requests compressed
+// responses, for servers behind a proxy that handles them; see
+// CompressionMiddleware.
+func WithCompression(cfg CompressionConfig) Option {
+	return func(o *clientOptions) { o.compress = &cfg }
+}
+
+// WithResponseCache serves recall and read-only GET requests from c and
+// invalidates it on writes; see ResponseCache.
+func WithResponseCache(c *ResponseCache) Option {
+	return func(o *clientOptions) { o.cache = c }
+}
+
+// WithHedging hedges slow recall and read-only GET requests with h; see
+// Hedger.
+func WithHedging(h *Hedger) Option {
+	return func(o *clientOptions) { o.hedger = h }
+}
+
+// WithSingleflight coalesces concurrent identical reads into one call; see
+// SingleflightMiddleware.
+func WithSingleflight() Option {
+	return func(o *clientOptions) { o.coalesce = true }
+}
+
+// WithRequestValidation validates request bodies before sending them, so
+// invalid arguments fail without a round trip; see RecallRequest.Validate.
+func WithRequestValidation() Option {
+	return func(o *clientOptions) { o.validate = true }
+}
+
+// WithMiddleware installs middleware outside the retry loop, in the order
+// given.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *clientOptions) { o.middleware = append(o.middleware, middleware...) }
+}
+
+// New creates an API client. Unless WithBaseURL is given, the server and API
+// key are resolved like the hindsight CLI does (see LoadCLIConfig), so a Go
+// tool talks to the same server the developer's CLI is configured for.
+//
+// Example:
+//
+//	client, err := hindsight.New(hindsight.WithTimeout(30*time.Second), hindsight.WithRetries(3))
+//	resp, err := client.Bank("agent-1").Recall(ctx, "What does Alice drink?")
+func New(opts ...Option) (*APIClient, error) {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var resolved *CLIConfig
+	switch {
+	case o.baseURL != "":
+		if !isUnixSocketURL(o.baseURL) {
+			if err := validateAPIURL(o.baseURL); err != nil {
+				return nil, err
+			}
+		}
+		resolved = &CLIConfig{APIURL: o.baseURL, APIKey: os.Getenv("HINDSIGHT_API_KEY"), Source: ConfigSourceOption}
+	case o.profile != "":
+		endpoint, err := DiscoverDaemon(o.profile)
+		if err != nil {
+			return nil, err
+		}
+		resolved = &CLIConfig{APIURL: endpoint.URL, APIKey: os.Getenv("HINDSIGHT_API_KEY"), Source: ConfigSourceProfile}
+	default:
+		path := o.configFile
+		if path == "" {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, ".hindsight", "config")
+			}
+		}
+		var err error
+		if resolved, err = loadCLIConfig(path); err != nil {
+			return nil, err
+		}
+	}
+	if o.apiKey != nil {
+		resolved.APIKey = *o.apiKey
+	}
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{
+		{URL: resolved.APIURL, Description: "resolved from " + resolved.Source.String()},
+	}
+	if resolved.APIKey != "" {
+		cfg.AddDefaultHeader("Authorization", "Bearer "+resolved.APIKey)
+	}
+	if o.userAgent != "" {
+		cfg.UserAgent = o.userAgent
+	}
+	cfg.ValidateRequests = o.validate
+	cfg.HTTPClient = &http.Client{Timeout: o.timeout, Transport: o.transport}
+	if isUnixSocketURL(resolved.APIURL) {
+		cfg.Use(UnixSocketMiddleware())
+	}
+	cfg.Use(DeadlineHeaderMiddleware())
+	if o.compress != nil {
+		cfg.Use(CompressionMiddleware(*o.compress))
+	}
+	if o.retry != nil {
+		cfg.Use(RetryMiddleware(*o.retry))
+	}
+	if o.maxBody > 0 {
+		// Outside retries: an oversized response is not worth repeating.
+		cfg.Use(MaxResponseSizeMiddleware(o.maxBody))
+	}
+	if o.hedger != nil {
+		cfg.Use(o.hedger.Middleware())
+	}
+	if o.coalesce {
+		cfg.Use(SingleflightMiddleware())
+	}
+	if o.cache != nil {
+		cfg.Use(o.cache.Middleware())
+	}
+	if len(o.middleware) > 0 {
+		cfg.Use(o.middleware...)
+	}
+	if o.timeouts != nil {
+		// Outermost, so time spent in user middleware such as a rate
+		// limiter counts towards the timeout.
+		cfg.Use(TimeoutMiddleware(*o.timeouts))
+	}
+	return NewAPIClient(cfg), nil
+}
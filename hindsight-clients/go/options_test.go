@@ -0,0 +1,167 @@
+package hindsight
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLoadCLIConfigPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+	// Parsed like the CLI: sections are ignored, the last non-empty value
+	// wins and the value ends at the next "=".
+	os.WriteFile(path, []byte(`api_url = "http://first:8888"
+api_key = 'file-key'
+
+[other]
+api_url = "http://file:8888"
+api_key =
+api_url_last = http://last:8888=extra
+`), 0o600)
+
+	unsetenv(t, "HINDSIGHT_API_URL")
+	unsetenv(t, "HINDSIGHT_API_KEY")
+	cfg, err := loadCLIConfig(path)
+	if err != nil || cfg.APIURL != "http://last:8888" || cfg.APIKey != "file-key" || cfg.Source != ConfigSourceFile {
+		t.Fatalf("file config: %+v, %v", cfg, err)
+	}
+
+	os.WriteFile(path, []byte("api_url = \"http://file:8888\"\napi_key = 'file-key'\n"), 0o600)
+	cfg, err = loadCLIConfig(path)
+	if err != nil || cfg.APIURL != "http://file:8888" || cfg.APIKey != "file-key" {
+		t.Fatalf("file config: %+v, %v", cfg, err)
+	}
+
+	t.Setenv("HINDSIGHT_API_KEY", "env-key")
+	cfg, _ = loadCLIConfig(path)
+	if cfg.APIKey != "env-key" {
+		t.Fatalf("HINDSIGHT_API_KEY should override the file key, got %q", cfg.APIKey)
+	}
+	// As in the CLI, a variable set to "" still counts.
+	t.Setenv("HINDSIGHT_API_KEY", "")
+	cfg, _ = loadCLIConfig(path)
+	if cfg.APIKey != "" {
+		t.Fatalf("an empty HINDSIGHT_API_KEY should clear the file key, got %q", cfg.APIKey)
+	}
+	t.Setenv("HINDSIGHT_API_URL", "")
+	if _, err := loadCLIConfig(path); err == nil {
+		t.Fatal("expected an error for an empty HINDSIGHT_API_URL")
+	}
+
+	t.Setenv("HINDSIGHT_API_URL", "https://env.example.com")
+	cfg, _ = loadCLIConfig(path)
+	if cfg.APIURL != "https://env.example.com" || cfg.Source != ConfigSourceEnvironment {
+		t.Fatalf("env config: %+v", cfg)
+	}
+
+	// A file without api_url is ignored, its api_key included.
+	unsetenv(t, "HINDSIGHT_API_URL")
+	unsetenv(t, "HINDSIGHT_API_KEY")
+	keyOnly := filepath.Join(dir, "key-only")
+	os.WriteFile(keyOnly, []byte("api_key = k\n"), 0o600)
+	for _, p := range []string{filepath.Join(dir, "absent"), keyOnly} {
+		cfg, _ = loadCLIConfig(p)
+		if cfg.APIURL != DefaultAPIURL || cfg.APIKey != "" || cfg.Source != ConfigSourceDefault {
+			t.Fatalf("default config from %s: %+v", p, cfg)
+		}
+	}
+
+	for _, url := range []string{"localhost:8888", "unix:///tmp/hindsight.sock"} {
+		t.Setenv("HINDSIGHT_API_URL", url)
+		if _, err := loadCLIConfig(path); err == nil {
+			t.Fatalf("expected an error for %s", url)
+		}
+	}
+}
+
+// unsetenv unsets key for the duration of the test.
+func unsetenv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	os.Unsetenv(key)
+}
+
+func TestNewWithProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("HINDSIGHT_API_KEY", "")
+	os.MkdirAll(filepath.Join(home, ".hindsight", "profiles"), 0o700)
+	os.WriteFile(filepath.Join(home, ".hindsight", "profiles", "metadata.json"),
+		[]byte(`{"version":1,"profiles":{"work":{"port":9100}}}`), 0o600)
+	os.WriteFile(filepath.Join(home, ".hindsight", "config"), []byte("api_url = https://file.example.com\n"), 0o600)
+
+	client, err := New(WithProfile("work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := client.GetConfig().Servers[0].URL; got != "http://127.0.0.1:9100" {
+		t.Fatalf("profile URL = %s", got)
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	var calls int32
+	var gotAuth, gotUA string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		gotAuth, gotUA = r.Header.Get("Authorization"), r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"banks":[]}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+	os.WriteFile(path, []byte("api_url = \""+srv.URL+"\"\napi_key = \"file-key\"\n"), 0o600)
+	unsetenv(t, "HINDSIGHT_API_URL")
+	unsetenv(t, "HINDSIGHT_API_KEY")
+
+	client, err := New(
+		WithConfigFile(path),
+		WithUserAgent("my-tool/1.0"),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 2, MinBackoff: 1, MaxBackoff: 1}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.BanksAPI.ListBanks(context.Background()).Execute(); err != nil {
+		t.Fatalf("request failed after retries: %v", err)
+	}
+	if calls != 3 || gotAuth != "Bearer file-key" || gotUA != "my-tool/1.0" {
+		t.Fatalf("calls=%d auth=%q ua=%q", calls, gotAuth, gotUA)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	noRetry, _ := New(WithBaseURL(srv.URL), WithAPIKey(""))
+	if _, _, err := noRetry.BanksAPI.ListBanks(context.Background()).Execute(); err == nil {
+		t.Fatal("expected the 503 to surface without retries")
+	}
+}
+
+func TestRetrySkipsNonIdempotentRequests(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	client, _ := New(WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{MaxRetries: 3, MinBackoff: 1, MaxBackoff: 1}))
+	client.Bank("b").Retain(context.Background(), *NewMemoryItem("x"))
+	if calls != 1 {
+		t.Fatalf("retain was sent %d times, want 1", calls)
+	}
+	atomic.StoreInt32(&calls, 0)
+	client.Bank("b").Recall(context.Background(), "x")
+	if calls != 4 {
+		t.Fatalf("recall was sent %d times, want 4", calls)
+	}
+}
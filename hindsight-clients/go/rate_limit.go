@@ -0,0 +1,406 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a request is rejected by the client-side
+// rate limiter, either because FailFast is set or because the caller's context
+// deadline expires before capacity becomes available.
+var ErrRateLimited = errors.New("hindsight: client-side rate limit exceeded")
+
+// RateLimitError is the typed error returned for rejected requests.
+// It matches ErrRateLimited with errors.Is.
+type RateLimitError struct {
+	Operation string
+	BankID    string
+	// Wait is how long the caller would have had to wait for capacity, or
+	// zero when the request was rejected by a concurrency cap.
+	Wait time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	s := fmt.Sprintf("%s: %s", ErrRateLimited, e.Operation)
+	if e.BankID != "" {
+		s += " bank=" + e.BankID
+	}
+	if e.Wait > 0 {
+		s += fmt.Sprintf(" (wait %s)", e.Wait.Round(time.Millisecond))
+	}
+	return s
+}
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RateLimitRule limits the request rate and concurrency of a set of requests.
+type RateLimitRule struct {
+	// RequestsPerSecond is the token bucket refill rate. Zero disables rate
+	// limiting for the rule.
+	RequestsPerSecond float64
+	// Burst is the token bucket capacity. Defaults to 1.
+	Burst int
+	// MaxInFlight caps the number of concurrent requests. Zero disables the cap.
+	MaxInFlight int
+	// PerBank applies the rule separately to every bank ID instead of
+	// sharing one limit across all banks.
+	PerBank bool
+}
+
+// RateLimiterConfig configures a RateLimiter.
+type RateLimiterConfig struct {
+	// Operations maps an operation name such as "MemoryAPIService.Reflect" to
+	// the rule applied to it.
+	Operations map[string]RateLimitRule
+	// Banks maps a bank ID to a rule shared by every operation on that bank,
+	// applied in addition to any operation rule. PerBank has no effect here.
+	Banks map[string]RateLimitRule
+	// FailFast rejects requests with ErrRateLimited instead of waiting for
+	// capacity.
+	FailFast bool
+}
+
+// RateLimitStats reports how a RateLimiter has treated requests for one operation.
+type RateLimitStats struct {
+	// Requests is the number of requests that passed the limiter.
+	Requests int64
+	// Delayed is the number of admitted requests that had to wait.
+	Delayed int64
+	// Rejected is the number of requests rejected with ErrRateLimited.
+	Rejected int64
+	// TotalWait is the cumulative time admitted requests spent waiting.
+	TotalWait time.Duration
+	// MaxWait is the longest time a single admitted request waited.
+	MaxWait time.Duration
+}
+
+// RateLimiter applies token-bucket rate limits and max-in-flight caps per
+// operation and per bank before requests reach the server. Install it with
+// Configuration.Use.
+//
+// A limiter with no request in flight and a full bucket is dropped, since a
+// new one would be identical, so PerBank rules only keep the banks in recent
+// use.
+type RateLimiter struct {
+	cfg RateLimiterConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	limiters map[rateLimitKey]*limiter
+	stats    map[string]*RateLimitStats
+	// sweepAt is the number of limiters at which idle ones are dropped.
+	sweepAt int
+}
+
+// minLimiterSweep is the smallest number of limiters that triggers a sweep.
+const minLimiterSweep = 64
+
+type rateLimitKey struct {
+	scope string // "op" or "bank"
+	name  string
+	bank  string
+}
+
+type limiter struct {
+	bucket *tokenBucket
+	sem    chan struct{}
+	// users counts the requests between limitersFor and their release.
+	users int
+}
+
+// NewRateLimiter returns a RateLimiter using cfg.
+func NewRateLimiter(cfg RateLimiterConfig) *RateLimiter {
+	return &RateLimiter{
+		cfg:      cfg,
+		now:      time.Now,
+		limiters: make(map[rateLimitKey]*limiter),
+		stats:    make(map[string]*RateLimitStats),
+		sweepAt:  minLimiterSweep,
+	}
+}
+
+// Middleware returns the limiter as a Middleware for Configuration.Use.
+func (l *RateLimiter) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			op := OperationForRequest(req)
+			release, err := l.acquire(req.Context(), op)
+			if err != nil {
+				closeRequestBody(req)
+				return nil, err
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil || resp.Body == nil {
+				release()
+				return resp, err
+			}
+			resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+			return resp, nil
+		})
+	}
+}
+
+// Stats returns a snapshot of the limiter statistics keyed by operation name.
+func (l *RateLimiter) Stats() map[string]RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make(map[string]RateLimitStats, len(l.stats))
+	for name, s := range l.stats {
+		out[name] = *s
+	}
+	return out
+}
+
+func (l *RateLimiter) limitersFor(op Operation) []*limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var out []*limiter
+	if rule, ok := l.cfg.Operations[op.Name]; ok {
+		key := rateLimitKey{scope: "op", name: op.Name}
+		if rule.PerBank {
+			key.bank = op.BankID
+		}
+		out = append(out, l.limiterLocked(key, rule))
+	}
+	if rule, ok := l.cfg.Banks[op.BankID]; ok && op.BankID != "" {
+		out = append(out, l.limiterLocked(rateLimitKey{scope: "bank", bank: op.BankID}, rule))
+	}
+	return out
+}
+
+func (l *RateLimiter) limiterLocked(key rateLimitKey, rule RateLimitRule) *limiter {
+	if lim, ok := l.limiters[key]; ok {
+		lim.users++
+		return lim
+	}
+	if len(l.limiters) >= l.sweepAt {
+		l.sweepLocked()
+	}
+	lim := &limiter{users: 1}
+	if rule.RequestsPerSecond > 0 {
+		burst := rule.Burst
+		if burst <= 0 {
+			burst = 1
+		}
+		lim.bucket = &tokenBucket{rate: rule.RequestsPerSecond, burst: float64(burst), tokens: float64(burst), last: l.now()}
+	}
+	if rule.MaxInFlight > 0 {
+		lim.sem = make(chan struct{}, rule.MaxInFlight)
+	}
+	l.limiters[key] = lim
+	return lim
+}
+
+// sweepLocked drops idle limiters and sets the next sweep threshold to twice
+// the number kept, so sweeps cost amortized constant time per new limiter.
+// Callers must hold l.mu.
+func (l *RateLimiter) sweepLocked() {
+	now := l.now()
+	for key, lim := range l.limiters {
+		if lim.users == 0 && (lim.bucket == nil || lim.bucket.full(now)) {
+			delete(l.limiters, key)
+		}
+	}
+	l.sweepAt = 2 * len(l.limiters)
+	if l.sweepAt < minLimiterSweep {
+		l.sweepAt = minLimiterSweep
+	}
+}
+
+// done ends the use of limiters by a request.
+func (l *RateLimiter) done(limiters []*limiter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, lim := range limiters {
+		lim.users--
+	}
+}
+
+// acquire takes a token from every bucket that applies to op, then a slot
+// in every concurrency cap. Tokens are reserved in all buckets at once and
+// the request waits for the slowest; if it is rejected at any point, every
+// reservation is cancelled so a rejected request costs no rate capacity.
+func (l *RateLimiter) acquire(ctx context.Context, op Operation) (func(), error) {
+	limiters := l.limitersFor(op)
+	if len(limiters) == 0 {
+		return func() {}, nil
+	}
+
+	start := l.now()
+	var reserved []*tokenBucket
+	var wait time.Duration
+	for _, lim := range limiters {
+		if lim.bucket == nil {
+			continue
+		}
+		if d := lim.bucket.reserve(start); d > wait {
+			wait = d
+		}
+		reserved = append(reserved, lim.bucket)
+	}
+	var held []chan struct{}
+	release := func() {
+		for _, sem := range held {
+			<-sem
+		}
+		l.done(limiters)
+	}
+	reject := func(wait time.Duration) (func(), error) {
+		for _, bucket := range reserved {
+			bucket.cancel()
+		}
+		release()
+		l.reject(op)
+		return nil, &RateLimitError{Operation: op.Name, BankID: op.BankID, Wait: wait}
+	}
+
+	if wait > 0 {
+		if err := l.sleep(ctx, wait); err != nil {
+			return reject(wait)
+		}
+	}
+	for _, lim := range limiters {
+		if lim.sem == nil {
+			continue
+		}
+		if err := l.enter(ctx, lim.sem); err != nil {
+			return reject(0)
+		}
+		held = append(held, lim.sem)
+	}
+
+	l.admit(op, l.now().Sub(start))
+	var once sync.Once
+	return func() { once.Do(release) }, nil
+}
+
+// sleep waits for d unless FailFast is set or ctx ends first. A request whose
+// deadline falls before d elapses is rejected immediately rather than after
+// waiting out its deadline.
+func (l *RateLimiter) sleep(ctx context.Context, d time.Duration) error {
+	if l.cfg.FailFast {
+		return ErrRateLimited
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(l.now()) < d {
+		return context.DeadlineExceeded
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *RateLimiter) enter(ctx context.Context, sem chan struct{}) error {
+	select {
+	case sem <- struct{}{}:
+		return nil
+	default:
+	}
+	if l.cfg.FailFast {
+		return ErrRateLimited
+	}
+	select {
+	case sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *RateLimiter) statsLocked(op Operation) *RateLimitStats {
+	s, ok := l.stats[op.Name]
+	if !ok {
+		s = &RateLimitStats{}
+		l.stats[op.Name] = s
+	}
+	return s
+}
+
+func (l *RateLimiter) admit(op Operation, waited time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := l.statsLocked(op)
+	s.Requests++
+	if waited > 0 {
+		s.Delayed++
+		s.TotalWait += waited
+		if waited > s.MaxWait {
+			s.MaxWait = waited
+		}
+	}
+}
+
+func (l *RateLimiter) reject(op Operation) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.statsLocked(op).Rejected++
+}
+
+// tokenBucket is a token bucket that hands out reservations. Reserving may
+// drive the token count negative; the returned duration is how long the
+// caller must wait for its token to become available.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// full reports whether the bucket has refilled to its burst by now.
+func (b *tokenBucket) full(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tokens+now.Sub(b.last).Seconds()*b.rate >= b.burst
+}
+
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// releasingBody frees the request's concurrency slots once the caller has
+// finished with the response body.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
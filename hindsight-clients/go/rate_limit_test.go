@@ -0,0 +1,193 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRateLimitedClient(t *testing.T, handler http.HandlerFunc, limiter *RateLimiter) *APIClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	cfg.Use(limiter.Middleware())
+	return NewAPIClient(cfg)
+}
+
+func TestRateLimiterMaxInFlight(t *testing.T) {
+	var inFlight, peak int32
+	limiter := NewRateLimiter(RateLimiterConfig{
+		Operations: map[string]RateLimitRule{
+			"MemoryAPIService.Reflect": {MaxInFlight: 2},
+		},
+	})
+	client := newRateLimitedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"text":"ok"}`))
+	}, limiter)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.MemoryAPI.Reflect(context.Background(), "b").ReflectRequest(ReflectRequest{Query: "q"}).Execute(); err != nil {
+				t.Errorf("reflect failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Fatalf("peak concurrency %d exceeds cap of 2", peak)
+	}
+	stats := limiter.Stats()["MemoryAPIService.Reflect"]
+	if stats.Requests != 6 || stats.Delayed == 0 || stats.TotalWait == 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestRateLimiterPerBankFailFast(t *testing.T) {
+	limiter := NewRateLimiter(RateLimiterConfig{
+		Operations: map[string]RateLimitRule{
+			"MemoryAPIService.RetainMemories": {RequestsPerSecond: 0.001, Burst: 1, PerBank: true},
+		},
+		FailFast: true,
+	})
+	client := newRateLimitedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"bank_id":"b","items_count":1,"async":false}`))
+	}, limiter)
+
+	ctx := context.Background()
+	req := RetainRequest{Items: []MemoryItem{{Content: "x"}}}
+	if _, _, err := client.MemoryAPI.RetainMemories(ctx, "a").RetainRequest(req).Execute(); err != nil {
+		t.Fatalf("first retain for bank a: %v", err)
+	}
+	if _, _, err := client.MemoryAPI.RetainMemories(ctx, "b").RetainRequest(req).Execute(); err != nil {
+		t.Fatalf("first retain for bank b: %v", err)
+	}
+	_, _, err := client.MemoryAPI.RetainMemories(ctx, "a").RetainRequest(req).Execute()
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) || rlErr.BankID != "a" || rlErr.Wait <= 0 {
+		t.Fatalf("unexpected error detail %#v", rlErr)
+	}
+	if got := limiter.Stats()["MemoryAPIService.RetainMemories"].Rejected; got != 1 {
+		t.Fatalf("rejected = %d, want 1", got)
+	}
+}
+
+func TestRateLimiterRespectsDeadline(t *testing.T) {
+	limiter := NewRateLimiter(RateLimiterConfig{
+		Banks: map[string]RateLimitRule{"noisy": {RequestsPerSecond: 1, Burst: 1}},
+	})
+	client := newRateLimitedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results":[]}`))
+	}, limiter)
+
+	req := RecallRequest{Query: "q"}
+	if _, _, err := client.MemoryAPI.RecallMemories(context.Background(), "noisy").RecallRequest(req).Execute(); err != nil {
+		t.Fatalf("first recall: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err := client.MemoryAPI.RecallMemories(ctx, "noisy").RecallRequest(req).Execute()
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Fatalf("expected immediate rejection, waited %s", elapsed)
+	}
+}
+
+func TestRateLimiterRejectionReturnsTokens(t *testing.T) {
+	limiter := NewRateLimiter(RateLimiterConfig{
+		Operations: map[string]RateLimitRule{
+			"MemoryAPIService.RecallMemories": {RequestsPerSecond: 0.001, Burst: 2},
+		},
+		Banks:    map[string]RateLimitRule{"noisy": {RequestsPerSecond: 0.001, Burst: 1}},
+		FailFast: true,
+	})
+	client := newRateLimitedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results":[]}`))
+	}, limiter)
+
+	ctx := context.Background()
+	req := RecallRequest{Query: "q"}
+	if _, _, err := client.MemoryAPI.RecallMemories(ctx, "noisy").RecallRequest(req).Execute(); err != nil {
+		t.Fatalf("first recall: %v", err)
+	}
+	// Rejected by the bank rule: the operation token it reserved is
+	// returned, so repeated rejections do not starve other banks.
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.MemoryAPI.RecallMemories(ctx, "noisy").RecallRequest(req).Execute(); !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("expected ErrRateLimited, got %v", err)
+		}
+	}
+	if _, _, err := client.MemoryAPI.RecallMemories(ctx, "quiet").RecallRequest(req).Execute(); err != nil {
+		t.Fatalf("recall for another bank: %v", err)
+	}
+}
+
+func TestRateLimiterDropsIdleLimiters(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter(RateLimiterConfig{
+		Operations: map[string]RateLimitRule{
+			"MemoryAPIService.RetainMemories": {RequestsPerSecond: 1, MaxInFlight: 1, PerBank: true},
+		},
+		Banks:    map[string]RateLimitRule{"slow": {RequestsPerSecond: 0.001}},
+		FailFast: true,
+	})
+	limiter.now = func() time.Time { return now }
+	rt := limiter.Middleware()(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	retain := func(bank string) error {
+		req, _ := http.NewRequest(http.MethodPost, "http://h/v1/default/banks/"+bank+"/memories", nil)
+		resp, err := rt.RoundTrip(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := retain("slow"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2000; i++ {
+		if err := retain(fmt.Sprint("bank-", i)); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(100 * time.Millisecond)
+		if n := len(limiter.limiters); n > 200 {
+			t.Fatalf("%d limiters after %d banks", n, i+1)
+		}
+	}
+	// The slow bank's bucket is still empty, so it was kept.
+	if err := retain("slow"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+}
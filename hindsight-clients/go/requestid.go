@@ -0,0 +1,105 @@
+package hindsight
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+)
+
+// RequestIDHeader is the header correlating a call with the server's logs.
+// Every request sends one and the server echoes it back.
+const RequestIDHeader = "X-Request-ID"
+
+// ContextRequestID sets the request ID sent with a call, so it can share the
+// ID of the operation that triggered it. Its value must be a string. Without
+// it, a random ID is generated per call.
+var ContextRequestID = contextKey("requestID")
+
+// WithRequestID returns a copy of ctx whose calls send id as their request
+// ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ContextRequestID, id)
+}
+
+// ResponseRequestID returns the request ID of the call that produced resp:
+// the ID echoed by the server, or the one that was sent if the server did
+// not echo it. It returns "" for a nil response.
+func ResponseRequestID(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+	if id := resp.Header.Get(RequestIDHeader); id != "" {
+		return id
+	}
+	if resp.Request != nil {
+		return resp.Request.Header.Get(RequestIDHeader)
+	}
+	return ""
+}
+
+// RequestIDFromError returns the request ID recorded in err, or "" if it
+// has none. Errors returned by Execute, BankClient methods and the
+// transport all carry the ID of the call that failed.
+func RequestIDFromError(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RequestID != "" {
+		return apiErr.RequestID
+	}
+	var withID interface{ RequestID() string }
+	if errors.As(err, &withID) {
+		return withID.RequestID()
+	}
+	return ""
+}
+
+// setRequestID returns req with RequestIDHeader set, unless the caller set
+// one already.
+func setRequestID(req *http.Request) *http.Request {
+	if req.Header.Get(RequestIDHeader) != "" {
+		return req
+	}
+	id, _ := req.Context().Value(ContextRequestID).(string)
+	if id == "" {
+		id = newRequestID()
+	}
+	req.Header.Set(RequestIDHeader, id)
+	return req
+}
+
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// requestError adds the request ID to an error returned before any response
+// was received, such as a network or client-side middleware error.
+type requestError struct {
+	requestID string
+	err       error
+}
+
+func withRequestID(req *http.Request, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &requestError{requestID: req.Header.Get(RequestIDHeader), err: err}
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error() + requestIDSuffix(e.requestID)
+}
+
+func (e *requestError) Unwrap() error { return e.err }
+
+// RequestID returns the ID of the request that failed.
+func (e *requestError) RequestID() string { return e.requestID }
+
+func requestIDSuffix(id string) string {
+	if id == "" {
+		return ""
+	}
+	return " (request ID " + id + ")"
+}
@@ -0,0 +1,67 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		sent = append(sent, id)
+		w.Header().Set(RequestIDHeader, id)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/profile") {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"detail":"bank not found"}`)
+			return
+		}
+		io.WriteString(w, `{"results":[]}`)
+	}))
+	defer srv.Close()
+
+	client, err := New(WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// A random ID is generated per call and echoed back.
+	_, resp, err := client.MemoryAPI.RecallMemories(ctx, "agent").RecallRequest(*NewRecallRequest("tea")).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := ResponseRequestID(resp); len(id) != 32 || id != sent[0] {
+		t.Fatalf("ResponseRequestID = %q, sent %q", id, sent[0])
+	}
+
+	// An ID on the context is propagated and recorded in errors.
+	_, err = client.Bank("agent").Profile(WithRequestID(ctx, "turn-42"))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != "turn-42" || sent[1] != "turn-42" {
+		t.Fatalf("err = %#v, sent %q", err, sent[1])
+	}
+	if !strings.Contains(err.Error(), "turn-42") || RequestIDFromError(err) != "turn-42" {
+		t.Fatalf("request ID missing from %q", err)
+	}
+	var openAPIErr *GenericOpenAPIError
+	if !errors.As(err, &openAPIErr) || openAPIErr.RequestID() != "turn-42" {
+		t.Fatalf("GenericOpenAPIError = %#v", openAPIErr)
+	}
+	if strings.Count(err.Error(), "turn-42") != 1 {
+		t.Fatalf("request ID repeated in %q", err)
+	}
+
+	// Transport errors carry the ID that was sent.
+	srv.Close()
+	_, err = client.Bank("agent").Recall(WithRequestID(ctx, "turn-43"), "tea")
+	if err == nil || RequestIDFromError(err) != "turn-43" || !strings.Contains(err.Error(), "turn-43") {
+		t.Fatalf("transport error = %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package hindsight
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures RetryMiddleware. Zero values select the documented
+// defaults.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// MinBackoff is the base delay before the first retry. Defaults to 200ms.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, including delays requested
+	// by a Retry-After header. Defaults to 5 seconds.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent also retries requests that may have side effects,
+	// such as retain. By default only idempotent requests are retried.
+	RetryNonIdempotent bool
+}
+
+// RetryMiddleware retries requests that failed with a network error or a
+// 429, 502, 503 or 504 response, using exponential backoff with full jitter
+// and honouring Retry-After. Requests whose body cannot be replayed are not
+// retried.
+func RetryMiddleware(policy RetryPolicy) Middleware {
+	if policy.MinBackoff <= 0 {
+		policy.MinBackoff = 200 * time.Millisecond
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 5 * time.Second
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if policy.MaxRetries <= 0 || !policy.RetryNonIdempotent && !idempotentRequest(req) {
+				return next.RoundTrip(req)
+			}
+			if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+				return next.RoundTrip(req)
+			}
+			for attempt := 0; ; attempt++ {
+				out := req
+				if attempt > 0 && req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					out = req.Clone(req.Context())
+					out.Body = body
+				}
+				resp, err := next.RoundTrip(out)
+				if attempt >= policy.MaxRetries || !retryable(resp, err) || req.Context().Err() != nil {
+					return resp, err
+				}
+				delay := policy.backoff(attempt, resp)
+				if resp != nil {
+					io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+					resp.Body.Close()
+				}
+				timer := time.NewTimer(delay)
+				select {
+				case <-req.Context().Done():
+					timer.Stop()
+					return nil, req.Context().Err()
+				case <-timer.C:
+				}
+			}
+		})
+	}
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			d := time.Duration(secs) * time.Second
+			if d > p.MaxBackoff {
+				d = p.MaxBackoff
+			}
+			return d
+		}
+	}
+	ceiling := p.MinBackoff << uint(attempt)
+	if ceiling <= 0 || ceiling > p.MaxBackoff {
+		ceiling = p.MaxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// idempotentRequest reports whether req can be repeated without changing
+// server state beyond the first attempt. Recall is a POST but only reads.
+func idempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return OperationForRequest(req).Class == OperationClassRecall
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package hindsight
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SingleflightMiddleware coalesces concurrent identical reads, such as many
+// goroutines fetching the same mental model, bank profile or recall, into
+// one HTTP call whose response each caller receives a copy of. Requests are
+// identical when they would share a ResponseCache entry: same method,
+// server, path, query, credentials and normalized recall body. Writes are
+// never coalesced. As with ResponseCache, install it before SetTokenSource.
+//
+// The shared call does not inherit any one caller's cancellation or
+// deadline. A caller whose context ends returns immediately with its
+// context's error, and the call itself is cancelled only once every caller
+// waiting on it has gone.
+func SingleflightMiddleware() Middleware {
+	g := &flightGroup{calls: make(map[string]*flightCall)}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet && OperationForRequest(req).Class != OperationClassRecall {
+				return next.RoundTrip(req)
+			}
+			key, _, ok := cacheKey(req)
+			if !ok {
+				return next.RoundTrip(req)
+			}
+			return g.roundTrip(next, markCredentialKeyed(req), key)
+		})
+	}
+}
+
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	resp *http.Response
+	body []byte
+	err  error
+}
+
+func (g *flightGroup) roundTrip(next http.RoundTripper, req *http.Request, key string) (*http.Response, error) {
+	closeRequestBody(req)
+	g.mu.Lock()
+	c, ok := g.calls[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(detachedContext{req.Context()})
+		out := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				g.mu.Unlock()
+				cancel()
+				return nil, err
+			}
+			out.Body = body
+		}
+		c = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.do(next, out, key, c)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.response(req)
+	case <-req.Context().Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			c.cancel()
+			if g.calls[key] == c {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, req.Context().Err()
+	}
+}
+
+func (g *flightGroup) do(next http.RoundTripper, req *http.Request, key string, c *flightCall) {
+	defer c.cancel()
+	resp, err := next.RoundTrip(req)
+	if err == nil {
+		c.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	c.resp, c.err = resp, err
+
+	g.mu.Lock()
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	close(c.done)
+}
+
+// response returns a copy of the shared response for one caller.
+func (c *flightCall) response(req *http.Request) (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	resp := *c.resp
+	resp.Header = c.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(c.body))
+	resp.ContentLength = int64(len(c.body))
+	resp.Request = req
+	return &resp, nil
+}
+
+// detachedContext keeps the values of its parent but not its deadline or
+// cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
@@ -0,0 +1,145 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleflight(t *testing.T) {
+	var calls, cancelled int32
+	release := make(chan struct{})
+	arrived := make(chan struct{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		arrived <- struct{}{}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			atomic.AddInt32(&cancelled, 1)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"mm1","bank_id":"agent","name":"Prefs","source_query":"q","content":"Alice prefers tea"}`))
+	}))
+	defer srv.Close()
+
+	client, err := New(WithBaseURL(srv.URL), WithSingleflight())
+	if err != nil {
+		t.Fatal(err)
+	}
+	models := client.Bank("agent").MentalModels()
+
+	// Concurrent identical reads share one call, and cancelling one caller
+	// leaves the others waiting.
+	ctx := context.Background()
+	cancelCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	errs := make(chan error, 9)
+	get := func(ctx context.Context) {
+		defer wg.Done()
+		mm, err := models.Get(ctx, "mm1")
+		if err == nil && mm.Content != "Alice prefers tea" {
+			err = errors.New("wrong content " + mm.Content)
+		}
+		errs <- err
+	}
+	wg.Add(1)
+	go get(cancelCtx)
+	<-arrived
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go get(ctx)
+	}
+	time.Sleep(100 * time.Millisecond) // let the others join the call
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled caller got %v", err)
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("server saw %d calls, want 1", n)
+	}
+
+	// Once every caller has gone, the shared call is cancelled.
+	release = make(chan struct{})
+	cancelCtx, cancel = context.WithCancel(ctx)
+	done := make(chan error)
+	go func() {
+		_, err := models.Get(cancelCtx, "mm1")
+		done <- err
+	}()
+	<-arrived
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&cancelled) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&cancelled) != 1 {
+		t.Fatal("abandoned call was not cancelled")
+	}
+}
+
+func TestSingleflightCredentials(t *testing.T) {
+	release := make(chan struct{})
+	arrived := make(chan struct{}, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"mm1","bank_id":"agent","name":"Prefs","source_query":"q","content":"` + r.Header.Get("Authorization") + `"}`))
+	}))
+	defer srv.Close()
+
+	client, err := New(WithBaseURL(srv.URL), WithSingleflight())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Concurrent reads with different per-call keys are not merged: each
+	// caller gets the response fetched with its own key.
+	tokens := []string{"Bearer admin", "Bearer reader"}
+	got := make([]string, len(tokens))
+	var wg sync.WaitGroup
+	for i, token := range tokens {
+		wg.Add(1)
+		go func(i int, token string) {
+			defer wg.Done()
+			mm, _, err := client.MentalModelsAPI.GetMentalModel(context.Background(), "agent", "mm1").Authorization(token).Execute()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			got[i] = mm.Content
+		}(i, token)
+	}
+	for range tokens {
+		select {
+		case <-arrived:
+		case <-time.After(5 * time.Second):
+			close(release)
+			t.Fatal("requests with different keys were coalesced")
+		}
+	}
+	close(release)
+	wg.Wait()
+	for i, token := range tokens {
+		if got[i] != token {
+			t.Fatalf("caller with %q got %q", token, got[i])
+		}
+	}
+}
@@ -0,0 +1,492 @@
+package hindsight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// ResponseTooLargeError is returned when a response body is larger than the
+// limit set with MaxResponseSizeMiddleware or WithMaxResponseSize.
+type ResponseTooLargeError struct {
+	// Operation is the API operation, for example "MemoryAPIService.RecallMemories",
+	// or empty if the request did not match a known route.
+	Operation string
+	Limit     int64
+	// ContentLength is the size announced by the server, or -1 if the body
+	// was cut off while being read.
+	ContentLength int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	op := e.Operation
+	if op == "" {
+		op = "response"
+	}
+	if e.ContentLength >= 0 {
+		return fmt.Sprintf("hindsight: %s: response of %d bytes exceeds the %d byte limit", op, e.ContentLength, e.Limit)
+	}
+	return fmt.Sprintf("hindsight: %s: response exceeds the %d byte limit", op, e.Limit)
+}
+
+// MaxResponseSizeMiddleware fails responses whose body is larger than limit
+// bytes. A response announcing a larger Content-Length fails before its body
+// is read; otherwise reading the body returns *ResponseTooLargeError once
+// limit bytes have been consumed, which Execute and ExecuteStream report as
+// their error. For compressed responses the limit applies to the decoded
+// body when the transport or CompressionMiddleware decompresses it.
+func MaxResponseSizeMiddleware(limit int64) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil || limit <= 0 {
+				return resp, err
+			}
+			tooLarge := &ResponseTooLargeError{
+				Operation:     OperationForRequest(req).Name,
+				Limit:         limit,
+				ContentLength: -1,
+			}
+			if resp.ContentLength > limit {
+				resp.Body.Close()
+				tooLarge.ContentLength = resp.ContentLength
+				return nil, tooLarge
+			}
+			resp.Body = &limitedBody{body: resp.Body, remaining: limit, tooLarge: tooLarge}
+			return resp, nil
+		})
+	}
+}
+
+// limitedBody returns tooLarge instead of reading past remaining bytes.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	tooLarge  error
+	err       error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	// Read one byte more than allowed to tell a body of exactly the limit
+	// from a larger one.
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.body.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = 0
+		b.err = b.tooLarge
+		return n, b.err
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
+
+// postJSON sends a JSON request to path and returns the response with its
+// body unread. Error responses are read and returned as
+// *GenericOpenAPIError, as Execute does.
+func (a *MemoryAPIService) postJSON(ctx context.Context, path string, body interface{}, authorization *string) (*http.Response, error) {
+	headers := map[string]string{"Content-Type": "application/json", "Accept": "application/json"}
+	if authorization != nil {
+		parameterAddToHeaderOrQuery(headers, "authorization", authorization, "simple", "")
+	}
+	req, err := a.client.prepareRequest(ctx, path, http.MethodPost, body, headers, url.Values{}, url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.client.callAPI(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return resp, err
+	}
+	newErr := &GenericOpenAPIError{body: respBody, error: resp.Status, requestID: ResponseRequestID(resp)}
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		var v HTTPValidationError
+		if err := a.client.decode(&v, respBody, resp.Header.Get("Content-Type")); err != nil {
+			newErr.error = err.Error()
+			return resp, newErr
+		}
+		newErr.error = formatErrorMessage(resp.Status, &v)
+		newErr.model = v
+	}
+	return resp, newErr
+}
+
+// ExecuteStream sends the recall request and returns an iterator that
+// decodes RecallResponse.Results one at a time as the body arrives, instead
+// of reading the whole response into memory first. The caller must call
+// Close on the iterator unless Next has returned false.
+//
+// Example:
+//
+//	it, _, err := client.MemoryAPI.RecallMemories(ctx, "agent-1").RecallRequest(req).ExecuteStream()
+//	if err != nil {
+//		return err
+//	}
+//	defer it.Close()
+//	for it.Next() {
+//		fmt.Println(it.Result().Text)
+//	}
+//	return it.Err()
+func (r ApiRecallMemoriesRequest) ExecuteStream() (*RecallResultIterator, *http.Response, error) {
+	localBasePath, err := r.ApiService.client.cfg.ServerURLWithContext(r.ctx, "MemoryAPIService.RecallMemories")
+	if err != nil {
+		return nil, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/default/banks/{bank_id}/memories/recall"
+	localVarPath = strings.Replace(localVarPath, "{"+"bank_id"+"}", url.PathEscape(parameterValueToString(r.bankId, "bankId")), -1)
+	if r.recallRequest == nil {
+		return nil, nil, reportError("recallRequest is required and must be specified")
+	}
+
+	resp, err := r.ApiService.postJSON(r.ctx, localVarPath, r.recallRequest, r.authorization)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &RecallResultIterator{body: resp.Body, dec: json.NewDecoder(resp.Body)}, resp, nil
+}
+
+// ReflectTraceCall is one entry of a reflect trace, passed to the callback
+// of ApiReflectRequest.ExecuteStream. Exactly one field is set.
+type ReflectTraceCall struct {
+	ToolCall *ReflectToolCall
+	LLMCall  *ReflectLLMCall
+}
+
+// ExecuteStream sends the reflect request and decodes the response straight
+// from the body instead of reading it into memory first. The trace's tool
+// and LLM calls, the bulk of a traced response, are passed to onTrace one at
+// a time as they are decoded and are not kept in the returned response; an
+// error from onTrace stops decoding and is returned. With a nil onTrace they
+// are kept in Trace as Execute does.
+//
+// Example:
+//
+//	out, _, err := client.MemoryAPI.Reflect(ctx, "agent-1").ReflectRequest(req).ExecuteStream(
+//		func(call hindsight.ReflectTraceCall) error {
+//			if call.ToolCall != nil {
+//				log.Printf("tool %s", call.ToolCall.Tool)
+//			}
+//			return nil
+//		})
+func (r ApiReflectRequest) ExecuteStream(onTrace func(ReflectTraceCall) error) (*ReflectResponse, *http.Response, error) {
+	localBasePath, err := r.ApiService.client.cfg.ServerURLWithContext(r.ctx, "MemoryAPIService.Reflect")
+	if err != nil {
+		return nil, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/default/banks/{bank_id}/reflect"
+	localVarPath = strings.Replace(localVarPath, "{"+"bank_id"+"}", url.PathEscape(parameterValueToString(r.bankId, "bankId")), -1)
+	if r.reflectRequest == nil {
+		return nil, nil, reportError("reflectRequest is required and must be specified")
+	}
+
+	resp, err := r.ApiService.postJSON(r.ctx, localVarPath, r.reflectRequest, r.authorization)
+	if err != nil {
+		return nil, resp, err
+	}
+	defer resp.Body.Close()
+	out, err := decodeReflectResponse(json.NewDecoder(resp.Body), onTrace)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+	return out, resp, nil
+}
+
+func decodeReflectResponse(dec *json.Decoder, onTrace func(ReflectTraceCall) error) (*ReflectResponse, error) {
+	var out ReflectResponse
+	if err := expectDelim(dec, '{', "reflect response"); err != nil {
+		return nil, err
+	}
+	sawText := false
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		switch key {
+		case "trace":
+			if err := decodeReflectTrace(dec, &out.Trace, onTrace); err != nil {
+				return nil, err
+			}
+		case "text":
+			sawText = true
+			fallthrough
+		default:
+			if err := decodeModelField(dec, &out, key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := dec.Token(); err != nil { // closing '}'
+		return nil, err
+	}
+	if !sawText {
+		return nil, fmt.Errorf("no value given for required property text")
+	}
+	return &out, nil
+}
+
+// decodeReflectTrace decodes a trace object or null into trace, passing the
+// calls to onTrace if it is not nil.
+func decodeReflectTrace(dec *json.Decoder, trace *NullableReflectTrace, onTrace func(ReflectTraceCall) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		trace.SetNull()
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("hindsight: reflect trace: expected object, got %v", tok)
+	}
+	var t ReflectTrace
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		if onTrace == nil || (key != "tool_calls" && key != "llm_calls") {
+			if err := decodeModelField(dec, &t, key); err != nil {
+				return err
+			}
+			continue
+		}
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			continue
+		}
+		if tok != json.Delim('[') {
+			return fmt.Errorf("hindsight: reflect trace: expected %s array, got %v", key, tok)
+		}
+		for dec.More() {
+			var call ReflectTraceCall
+			if key == "tool_calls" {
+				call.ToolCall = new(ReflectToolCall)
+				err = dec.Decode(call.ToolCall)
+			} else {
+				call.LLMCall = new(ReflectLLMCall)
+				err = dec.Decode(call.LLMCall)
+			}
+			if err != nil {
+				return err
+			}
+			if err := onTrace(call); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil { // closing ']'
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil { // closing '}'
+		return err
+	}
+	trace.Set(&t)
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim, what string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("hindsight: %s: expected %v, got %v", what, delim, tok)
+	}
+	return nil
+}
+
+// RecallResultIterator walks the results of a streamed recall response. Use
+// it like bufio.Scanner: call Next until it returns false, then check Err.
+// Stopping early and calling Close discards the rest of the body unread.
+type RecallResultIterator struct {
+	body    io.ReadCloser
+	dec     *json.Decoder
+	current RecallResult
+	rest    RecallResponse
+
+	started    bool // the opening '{' has been read
+	inResults  bool // positioned inside the results array
+	sawResults bool
+	done       bool
+	err        error
+}
+
+// Next decodes the next result, reporting false when there are no more
+// results or an error occurred.
+func (it *RecallResultIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	if !it.inResults && (!it.advance() || !it.inResults) {
+		return false
+	}
+	if it.dec.More() {
+		var result RecallResult
+		if err := it.dec.Decode(&result); err != nil {
+			return it.fail(err)
+		}
+		it.current = result
+		return true
+	}
+	if _, err := it.dec.Token(); err != nil { // closing ']'
+		return it.fail(err)
+	}
+	it.inResults = false
+	it.advance()
+	return false
+}
+
+// advance decodes the top-level fields other than results until it reaches
+// the results array or the end of the object.
+func (it *RecallResultIterator) advance() bool {
+	if !it.started {
+		it.started = true
+		if tok, err := it.dec.Token(); err != nil {
+			return it.fail(err)
+		} else if tok != json.Delim('{') {
+			return it.fail(fmt.Errorf("hindsight: recall response: expected object, got %v", tok))
+		}
+	}
+	for it.dec.More() {
+		tok, err := it.dec.Token()
+		if err != nil {
+			return it.fail(err)
+		}
+		key, _ := tok.(string)
+		if key != "results" {
+			if err := decodeModelField(it.dec, &it.rest, key); err != nil {
+				return it.fail(err)
+			}
+			continue
+		}
+		it.sawResults = true
+		tok, err = it.dec.Token()
+		if err != nil {
+			return it.fail(err)
+		}
+		if tok == nil {
+			continue
+		}
+		if tok != json.Delim('[') {
+			return it.fail(fmt.Errorf("hindsight: recall response: expected results array, got %v", tok))
+		}
+		it.inResults = true
+		return true
+	}
+	if _, err := it.dec.Token(); err != nil { // closing '}'
+		return it.fail(err)
+	}
+	if !it.sawResults {
+		return it.fail(fmt.Errorf("no value given for required property results"))
+	}
+	it.Close()
+	return true
+}
+
+func (it *RecallResultIterator) fail(err error) bool {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	it.err = err
+	it.Close()
+	return false
+}
+
+// Result returns the result decoded by the last call to Next.
+func (it *RecallResultIterator) Result() RecallResult {
+	return it.current
+}
+
+// Err returns the first error met while decoding, such as a
+// *ResponseTooLargeError from the body or a JSON syntax error.
+func (it *RecallResultIterator) Err() error {
+	return it.err
+}
+
+// Response returns the fields of the response other than Results, such as
+// Trace, Entities and Chunks. Fields the server sends after the results are
+// only filled in once Next has returned false with a nil Err.
+func (it *RecallResultIterator) Response() *RecallResponse {
+	return &it.rest
+}
+
+// Close releases the response body and ends the iteration. It is safe to
+// call more than once.
+func (it *RecallResultIterator) Close() error {
+	it.done = true
+	if it.body == nil {
+		return nil
+	}
+	err := it.body.Close()
+	it.body = nil
+	return err
+}
+
+// decodeModelField decodes the next value of dec into the field of the
+// model struct pointed to by model whose JSON name is key, rejecting unknown
+// keys as the generated models do.
+func decodeModelField(dec *json.Decoder, model interface{}, key string) error {
+	v := reflect.ValueOf(model).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); name == key {
+			return dec.Decode(v.Field(i).Addr().Interface())
+		}
+	}
+	return fmt.Errorf("json: unknown field %q", key)
+}
+
+// RecallStream is Recall returning an iterator over the results; see
+// ApiRecallMemoriesRequest.ExecuteStream.
+func (b *BankClient) RecallStream(ctx context.Context, query string, opts ...RecallOption) (*RecallResultIterator, error) {
+	req := NewRecallRequest(query)
+	for _, opt := range opts {
+		opt(req)
+	}
+	it, resp, err := b.client.MemoryAPI.RecallMemories(ctx, b.id).RecallRequest(*req).ExecuteStream()
+	return it, b.wrap("MemoryAPIService.RecallMemories", resp, err)
+}
+
+// ReflectStream is Reflect decoding straight from the response body; see
+// ApiReflectRequest.ExecuteStream.
+func (b *BankClient) ReflectStream(ctx context.Context, query string, onTrace func(ReflectTraceCall) error, opts ...ReflectOption) (*ReflectResponse, error) {
+	req := NewReflectRequest(query)
+	for _, opt := range opts {
+		opt(req)
+	}
+	out, resp, err := b.client.MemoryAPI.Reflect(ctx, b.id).ReflectRequest(*req).ExecuteStream(onTrace)
+	return out, b.wrap("MemoryAPIService.Reflect", resp, err)
+}
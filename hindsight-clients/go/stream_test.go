@@ -0,0 +1,239 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecallStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"trace":{"steps":2},"results":[`)
+		for i := 0; i < 3; i++ {
+			if i > 0 {
+				io.WriteString(w, ",")
+			}
+			fmt.Fprintf(w, `{"id":"m%d","text":"fact %d"}`, i, i)
+			w.(http.Flusher).Flush()
+		}
+		io.WriteString(w, `],"chunks":{"c1":{"id":"c1","text":"chunk","chunk_index":0}}}`)
+	}))
+	defer srv.Close()
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	bank := NewAPIClient(cfg).Bank("agent")
+	ctx := context.Background()
+
+	it, err := bank.RecallStream(ctx, "facts", RecallChunks(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var texts []string
+	for it.Next() {
+		texts = append(texts, it.Result().Text)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(texts, ",") != "fact 0,fact 1,fact 2" {
+		t.Fatalf("results = %v", texts)
+	}
+	rest := it.Response()
+	if rest.Trace["steps"] != 2.0 || rest.Chunks["c1"].Text != "chunk" || rest.Results != nil {
+		t.Fatalf("rest of response = %+v", rest)
+	}
+
+	// Stopping early leaves the rest unread.
+	it, err = bank.RecallStream(ctx, "facts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next() || it.Result().Id != "m0" {
+		t.Fatalf("first result = %+v, err %v", it.Result(), it.Err())
+	}
+	it.Close()
+	if it.Next() || it.Err() != nil {
+		t.Fatalf("Next after Close = true or err %v", it.Err())
+	}
+}
+
+func TestRecallStreamErrors(t *testing.T) {
+	for _, tc := range []struct {
+		body, err string
+	}{
+		{`{"trace":{}}`, "no value given for required property results"},
+		{`{"results":[{"id":"m0","text":"a"},`, "unexpected"}, // EOF or end of JSON input, by Go release
+		{`{"results":[],"bogus":1}`, `json: unknown field "bogus"`},
+	} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, tc.body)
+		}))
+		cfg := NewConfiguration()
+		cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+		it, _, err := NewAPIClient(cfg).MemoryAPI.RecallMemories(context.Background(), "agent").
+			RecallRequest(*NewRecallRequest("q")).ExecuteStream()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for it.Next() {
+		}
+		if it.Err() == nil || !strings.Contains(it.Err().Error(), tc.err) {
+			t.Errorf("%s: Err() = %v, want %q", tc.body, it.Err(), tc.err)
+		}
+		srv.Close()
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	big := `{"text":"` + strings.Repeat("x", 2000) + `"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/default/banks/agent/reflect" {
+			w.Write([]byte(big))
+			return
+		}
+		// Chunked: no Content-Length to check up front.
+		io.WriteString(w, `{"results":[`)
+		w.(http.Flusher).Flush()
+		for i := 0; i < 100; i++ {
+			if i > 0 {
+				io.WriteString(w, ",")
+			}
+			fmt.Fprintf(w, `{"id":"m%d","text":"%s"}`, i, strings.Repeat("y", 100))
+		}
+		io.WriteString(w, `]}`)
+	}))
+	defer srv.Close()
+
+	client, err := New(WithBaseURL(srv.URL), WithMaxResponseSize(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	_, _, err = client.MemoryAPI.Reflect(ctx, "agent").ReflectRequest(*NewReflectRequest("q")).Execute()
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.ContentLength != int64(len(big)) || tooLarge.Operation != "MemoryAPIService.Reflect" {
+		t.Fatalf("Execute error = %v", err)
+	}
+
+	it, err := client.Bank("agent").RecallStream(ctx, "q")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if !errors.As(it.Err(), &tooLarge) || tooLarge.ContentLength != -1 || n == 0 || n >= 10 {
+		t.Fatalf("after %d results, Err() = %v", n, it.Err())
+	}
+
+	// A body of exactly the limit is accepted.
+	body := &limitedBody{body: io.NopCloser(strings.NewReader("12345")), remaining: 5, tooLarge: errors.New("too large")}
+	if b, err := io.ReadAll(body); err != nil || string(b) != "12345" {
+		t.Fatalf("ReadAll = %q, %v", b, err)
+	}
+}
+
+func TestRecallStreamPath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.EscapedPath() != "/v1/default/banks/team%2Fagent/memories/recall" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"detail":"not found"}`)
+			return
+		}
+		io.WriteString(w, `{"results":[]}`)
+	}))
+	defer srv.Close()
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL}}
+	api := NewAPIClient(cfg).MemoryAPI
+	ctx := context.Background()
+
+	it, _, err := api.RecallMemories(ctx, "team/agent").RecallRequest(*NewRecallRequest("q")).ExecuteStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if it.Next() || it.Err() != nil {
+		t.Fatalf("Next = true or err %v", it.Err())
+	}
+	_, resp, err := api.RecallMemories(ctx, "other").RecallRequest(*NewRecallRequest("q")).ExecuteStream()
+	var apiErr *GenericOpenAPIError
+	if !errors.As(err, &apiErr) || resp.StatusCode != http.StatusNotFound || !strings.Contains(string(apiErr.Body()), "not found") {
+		t.Fatalf("error = %v", err)
+	}
+}
+
+func TestReflectExecuteStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/default/banks/huge/reflect" {
+			// Chunked: no Content-Length to check up front.
+			io.WriteString(w, `{"text":"t","trace":{"tool_calls":[`)
+			w.(http.Flusher).Flush()
+			for i := 0; i < 100; i++ {
+				if i > 0 {
+					io.WriteString(w, ",")
+				}
+				fmt.Fprintf(w, `{"tool":"recall","input":{"q":"%s"},"duration_ms":1}`, strings.Repeat("y", 100))
+			}
+			io.WriteString(w, `]}}`)
+			return
+		}
+		io.WriteString(w, `{"trace":{"tool_calls":[{"tool":"recall","input":{},"duration_ms":3}],`+
+			`"llm_calls":[{"scope":"final","duration_ms":9}]},"text":"Alice drinks tea","usage":null}`)
+	}))
+	defer srv.Close()
+
+	client, err := New(WithBaseURL(srv.URL), WithMaxResponseSize(2000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bank := client.Bank("agent")
+	ctx := context.Background()
+
+	var calls []string
+	out, err := bank.ReflectStream(ctx, "q", func(call ReflectTraceCall) error {
+		if call.ToolCall != nil {
+			calls = append(calls, call.ToolCall.Tool)
+		} else {
+			calls = append(calls, call.LLMCall.Scope)
+		}
+		return nil
+	})
+	if err != nil || out.Text != "Alice drinks tea" || !out.Usage.IsNull() {
+		t.Fatalf("ReflectStream = %+v, %v", out, err)
+	}
+	if strings.Join(calls, ",") != "recall,final" || len(out.Trace.Get().ToolCalls) != 0 {
+		t.Fatalf("calls = %v, trace = %+v", calls, out.Trace.Get())
+	}
+
+	// Without a callback the trace is kept, as Execute does.
+	out, err = bank.ReflectStream(ctx, "q", nil)
+	if err != nil || len(out.Trace.Get().ToolCalls) != 1 || len(out.Trace.Get().LlmCalls) != 1 {
+		t.Fatalf("ReflectStream(nil) = %+v, %v", out, err)
+	}
+
+	stop := errors.New("stop")
+	if _, err := bank.ReflectStream(ctx, "q", func(ReflectTraceCall) error { return stop }); !errors.Is(err, stop) {
+		t.Fatalf("callback error = %v", err)
+	}
+
+	n := 0
+	_, err = client.Bank("huge").ReflectStream(ctx, "q", func(ReflectTraceCall) error { n++; return nil })
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || n == 0 || n >= 20 {
+		t.Fatalf("after %d calls, err = %v", n, err)
+	}
+}
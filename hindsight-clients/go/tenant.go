@@ -0,0 +1,103 @@
+package hindsight
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultTenant is the namespace segment the generated services use in every
+// /v1 path.
+const DefaultTenant = "default"
+
+// ContextTenant overrides the tenant namespace segment for a single request.
+// Its value must be a string. It is honoured once tenant routing is enabled
+// with Configuration.SetTenant or through a TenantClient.
+var ContextTenant = contextKey("tenant")
+
+// WithTenant returns a copy of ctx that routes requests to tenant.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, ContextTenant, tenant)
+}
+
+// SetTenant routes every /v1 request to the namespace segment tenant instead
+// of the hard-coded "default", so that /v1/default/banks/{bank_id} becomes
+// /v1/{tenant}/banks/{bank_id}. A ContextTenant value on the request context
+// takes precedence. Pass DefaultTenant to keep the default namespace while
+// still honouring per-request overrides.
+//
+// Call SetTenant once, before NewAPIClient.
+func (c *Configuration) SetTenant(tenant string) {
+	c.Use(tenantMiddleware(tenant))
+}
+
+func tenantMiddleware(tenant string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			t := tenant
+			if v, ok := ctx.Value(ContextTenant).(string); ok && v != "" {
+				t = v
+			} else {
+				ctx = WithTenant(ctx, t)
+			}
+			return next.RoundTrip(rewriteTenant(req.WithContext(ctx), t))
+		})
+	}
+}
+
+// rewriteTenant returns req with the namespace segment of its path replaced
+// by tenant. Requests outside the /v1 routes are returned unchanged.
+func rewriteTenant(req *http.Request, tenant string) *http.Request {
+	segments := splitPath(req)
+	r, op, offset, ok := matchRoute(req, segments)
+	if !ok || op.Tenant == "" || op.Tenant == tenant {
+		return req
+	}
+	index := -1
+	for i, s := range r.segments {
+		if s == "{tenant}" {
+			index = offset + i
+			break
+		}
+	}
+	segments[index] = url.PathEscape(tenant)
+	rawPath := "/" + strings.Join(segments, "/")
+	if strings.HasSuffix(req.URL.EscapedPath(), "/") {
+		rawPath += "/"
+	}
+	path, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return req
+	}
+
+	out := req.Clone(req.Context())
+	out.URL.Path = path
+	out.URL.RawPath = rawPath
+	if out.URL.EscapedPath() != rawPath {
+		out.URL.RawPath = ""
+	}
+	return out
+}
+
+// TenantClient is a view of an APIClient with every service scoped to one
+// tenant namespace. It shares the parent client's transport and connection
+// pool.
+type TenantClient struct {
+	*APIClient
+	tenant string
+}
+
+// Tenant returns a TenantClient that routes all requests to tenant. A
+// ContextTenant value on an individual request still takes precedence.
+func (c *APIClient) Tenant(tenant string) *TenantClient {
+	cfg := c.cfg.Clone()
+	cfg.SetTenant(tenant)
+	return &TenantClient{APIClient: NewAPIClient(cfg), tenant: tenant}
+}
+
+// Name returns the tenant namespace the client is scoped to.
+func (c *TenantClient) Name() string {
+	return c.tenant
+}
@@ -0,0 +1,49 @@
+package hindsight
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTenantRouting(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"banks":[]}`))
+	}))
+	defer srv.Close()
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: srv.URL + "/proxy"}}
+	cfg.SetTenant("acme")
+	client := NewAPIClient(cfg)
+	ctx := context.Background()
+
+	client.BanksAPI.ListBanks(ctx).Execute()
+	client.BanksAPI.ListBanks(WithTenant(ctx, "globex inc")).Execute()
+	client.MonitoringAPI.GetVersion(ctx).Execute()
+
+	scoped := client.Tenant("initech")
+	scoped.BanksAPI.GetAgentStats(ctx, "bank/1").Execute()
+	if scoped.Name() != "initech" {
+		t.Fatalf("Name() = %q", scoped.Name())
+	}
+
+	want := []string{
+		"/proxy/v1/acme/banks",
+		"/proxy/v1/globex%20inc/banks",
+		"/proxy/version",
+		"/proxy/v1/initech/banks/bank%2F1/stats",
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("path %d = %q, want %q", i, paths[i], want[i])
+		}
+	}
+}
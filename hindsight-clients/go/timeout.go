@@ -0,0 +1,114 @@
+package hindsight
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DeadlineHeader carries the time left before the caller's deadline, in
+// whole milliseconds. hindsight-api does not read it yet; it is there for
+// proxies and request logs, and for servers that can stop work the client
+// will no longer wait for.
+const DeadlineHeader = "X-Hindsight-Timeout-Ms"
+
+// OperationTimeouts sets a default timeout per operation. A timeout only
+// applies when the caller's context has no deadline of its own. Zero values
+// select the documented defaults and a negative value disables the timeout.
+type OperationTimeouts struct {
+	// Default applies to operations outside the retain, recall and reflect
+	// classes, such as bank and document management. Defaults to 30 seconds.
+	Default time.Duration
+	// Recall defaults to 10 seconds.
+	Recall time.Duration
+	// Retain defaults to 2 minutes.
+	Retain time.Duration
+	// Reflect covers reflect and consolidation. Defaults to 5 minutes.
+	Reflect time.Duration
+	// Operations maps an operation name such as "FilesAPIService.FileRetain"
+	// to a timeout that overrides its class. FileRetain defaults to 10
+	// minutes.
+	Operations map[string]time.Duration
+}
+
+// TimeoutMiddleware gives each request the timeout of its operation unless
+// the caller's context already has a deadline. Install it outside retry
+// middleware so the timeout bounds every attempt together.
+func TimeoutMiddleware(timeouts OperationTimeouts) Middleware {
+	if timeouts.Default == 0 {
+		timeouts.Default = 30 * time.Second
+	}
+	if timeouts.Recall == 0 {
+		timeouts.Recall = 10 * time.Second
+	}
+	if timeouts.Retain == 0 {
+		timeouts.Retain = 2 * time.Minute
+	}
+	if timeouts.Reflect == 0 {
+		timeouts.Reflect = 5 * time.Minute
+	}
+	operations := map[string]time.Duration{"FilesAPIService.FileRetain": 10 * time.Minute}
+	for name, d := range timeouts.Operations {
+		operations[name] = d
+	}
+	timeouts.Operations = operations
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if _, ok := req.Context().Deadline(); ok {
+				return next.RoundTrip(req)
+			}
+			d := timeouts.forOperation(OperationForRequest(req))
+			if d < 0 {
+				return next.RoundTrip(req)
+			}
+			ctx, cancel := context.WithTimeout(req.Context(), d)
+			resp, err := next.RoundTrip(req.WithContext(ctx))
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			// The timeout keeps running while the body is read.
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		})
+	}
+}
+
+func (t OperationTimeouts) forOperation(op Operation) time.Duration {
+	if d, ok := t.Operations[op.Name]; ok {
+		return d
+	}
+	switch op.Class {
+	case OperationClassRecall:
+		return t.Recall
+	case OperationClassRetain:
+		return t.Retain
+	case OperationClassReflect:
+		return t.Reflect
+	}
+	return t.Default
+}
+
+// DeadlineHeaderMiddleware sets DeadlineHeader on requests whose context has
+// a deadline. Install it inside retry middleware so every attempt reports
+// the time actually left. New and the NewAPIClientWith* constructors install
+// it.
+func DeadlineHeaderMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			deadline, ok := req.Context().Deadline()
+			if !ok {
+				return next.RoundTrip(req)
+			}
+			ms := time.Until(deadline).Milliseconds()
+			if ms < 1 {
+				ms = 1
+			}
+			out := req.Clone(req.Context())
+			out.Header.Set(DeadlineHeader, strconv.FormatInt(ms, 10))
+			return next.RoundTrip(out)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOperationTimeouts(t *testing.T) {
+	var mu sync.Mutex
+	var remaining []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		ms, _ := strconv.Atoi(r.Header.Get(DeadlineHeader))
+		mu.Lock()
+		remaining = append(remaining, ms)
+		mu.Unlock()
+		select {
+		case <-time.After(150 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"results":[{"id":"m1","text":"Alice prefers tea"}]}`)
+	}))
+	defer srv.Close()
+
+	client, err := New(WithBaseURL(srv.URL), WithOperationTimeouts(OperationTimeouts{Recall: 50 * time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bank := client.Bank("agent")
+	ctx := context.Background()
+
+	// Without a deadline, recall gets its operation's timeout.
+	if _, err := bank.Recall(ctx, "tea"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Recall err = %v, want the recall timeout", err)
+	}
+	// The caller's own deadline wins, even when it is longer.
+	deadlineCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err := bank.Recall(deadlineCtx, "tea"); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(remaining) != 2 || remaining[0] < 1 || remaining[0] > 50 || remaining[1] < 4000 || remaining[1] > 5000 {
+		t.Fatalf("%s values = %v", DeadlineHeader, remaining)
+	}
+}
+
+func TestOperationTimeoutsForOperation(t *testing.T) {
+	mw := TimeoutMiddleware(OperationTimeouts{
+		Retain:     time.Minute,
+		Operations: map[string]time.Duration{"BanksAPIService.ListBanks": -1},
+	})
+	var got []time.Duration
+	rt := mw(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		deadline, ok := req.Context().Deadline()
+		if !ok {
+			got = append(got, -1)
+		} else {
+			got = append(got, time.Until(deadline).Round(time.Minute))
+		}
+		return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+	}))
+	for _, path := range []string{
+		"/v1/default/banks/agent/memories",
+		"/v1/default/banks/agent/reflect",
+		"/v1/default/banks/agent/files/retain",
+		"/v1/default/banks",
+	} {
+		method := http.MethodPost
+		if path == "/v1/default/banks" {
+			method = http.MethodGet
+		}
+		req, _ := http.NewRequest(method, "http://localhost"+path, nil)
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	want := []time.Duration{time.Minute, 5 * time.Minute, 10 * time.Minute, -1}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("timeouts = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDeadlineHeaderConstructors(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(DeadlineHeader)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"banks":[]}`)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	for name, client := range map[string]*APIClient{
+		"NewAPIClientWithToken":       NewAPIClientWithToken(srv.URL, "token"),
+		"NewAPIClientWithTimeout":     NewAPIClientWithTimeout(srv.URL, "token", time.Minute),
+		"NewAPIClientWithTokenSource": NewAPIClientWithTokenSource(srv.URL, StaticTokenSource("token")),
+	} {
+		got = ""
+		if _, _, err := client.BanksAPI.ListBanks(ctx).Execute(); err != nil {
+			t.Fatal(err)
+		}
+		if ms, _ := strconv.Atoi(got); ms < 50000 || ms > 60000 {
+			t.Fatalf("%s: %s = %q", name, DeadlineHeader, got)
+		}
+	}
+}
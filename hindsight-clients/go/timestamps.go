@@ -0,0 +1,179 @@
+package hindsight
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Most timestamps in the API are typed as strings in the OpenAPI document.
+// The accessors below parse them into time.Time. Each returns the zero Time
+// and a nil error when the field is unset, null or empty, and a
+// *TimestampError when the value cannot be parsed.
+
+// timestampLayouts are the forms of Python's datetime.isoformat and
+// date.isoformat. Fractional seconds are accepted after the seconds in any
+// of them.
+var timestampLayouts = []string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseTimestamp parses a timestamp as the server emits it: ISO 8601 with
+// optional fractional seconds and a "Z" or "+hh:mm" offset, or a plain date.
+// A timestamp with an offset keeps it, so t.Equal compares instants
+// correctly across zones. One without an offset is in UTC, as the server
+// treats it.
+func ParseTimestamp(s string) (time.Time, error) {
+	// str(datetime) separates the date and time with a space.
+	if len(s) > 10 && s[10] == ' ' {
+		s = s[:10] + "T" + s[11:]
+	}
+	var firstErr error
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return time.Time{}, firstErr
+}
+
+// TimestampError reports a timestamp field whose value could not be parsed.
+type TimestampError struct {
+	// Field is the JSON name of the field, such as "occurred_start".
+	Field string
+	// Value is the unparsed value.
+	Value string
+	Err   error
+}
+
+func (e *TimestampError) Error() string {
+	return "hindsight: invalid " + e.Field + " timestamp " + strconv.Quote(e.Value) + ": " + e.Err.Error()
+}
+
+func (e *TimestampError) Unwrap() error { return e.Err }
+
+func parseTimestampField(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := ParseTimestamp(value)
+	if err != nil {
+		return time.Time{}, &TimestampError{Field: field, Value: value, Err: err}
+	}
+	return t, nil
+}
+
+// OccurredStartTime returns OccurredStart, when the remembered event began.
+func (o *RecallResult) OccurredStartTime() (time.Time, error) {
+	return parseTimestampField("occurred_start", o.GetOccurredStart())
+}
+
+// OccurredEndTime returns OccurredEnd, when the remembered event ended.
+func (o *RecallResult) OccurredEndTime() (time.Time, error) {
+	return parseTimestampField("occurred_end", o.GetOccurredEnd())
+}
+
+// MentionedAtTime returns MentionedAt, when the fact was retained.
+func (o *RecallResult) MentionedAtTime() (time.Time, error) {
+	return parseTimestampField("mentioned_at", o.GetMentionedAt())
+}
+
+// CreatedAtTime returns CreatedAt.
+func (o *OperationStatusResponse) CreatedAtTime() (time.Time, error) {
+	return parseTimestampField("created_at", o.GetCreatedAt())
+}
+
+// UpdatedAtTime returns UpdatedAt.
+func (o *OperationStatusResponse) UpdatedAtTime() (time.Time, error) {
+	return parseTimestampField("updated_at", o.GetUpdatedAt())
+}
+
+// CompletedAtTime returns CompletedAt.
+func (o *OperationStatusResponse) CompletedAtTime() (time.Time, error) {
+	return parseTimestampField("completed_at", o.GetCompletedAt())
+}
+
+// CreatedAtTime returns CreatedAt.
+func (o *DocumentResponse) CreatedAtTime() (time.Time, error) {
+	return parseTimestampField("created_at", o.GetCreatedAt())
+}
+
+// UpdatedAtTime returns UpdatedAt.
+func (o *DocumentResponse) UpdatedAtTime() (time.Time, error) {
+	return parseTimestampField("updated_at", o.GetUpdatedAt())
+}
+
+// CreatedAtTime returns CreatedAt.
+func (o *MentalModelResponse) CreatedAtTime() (time.Time, error) {
+	return parseTimestampField("created_at", o.GetCreatedAt())
+}
+
+// LastRefreshedAtTime returns LastRefreshedAt.
+func (o *MentalModelResponse) LastRefreshedAtTime() (time.Time, error) {
+	return parseTimestampField("last_refreshed_at", o.GetLastRefreshedAt())
+}
+
+// LastConsolidatedAtTime returns LastConsolidatedAt.
+func (o *BankStatsResponse) LastConsolidatedAtTime() (time.Time, error) {
+	return parseTimestampField("last_consolidated_at", o.GetLastConsolidatedAt())
+}
+
+// QueryTime returns QueryTimestamp, the point in time the recall is made
+// as of.
+func (o *RecallRequest) QueryTime() (time.Time, error) {
+	return parseTimestampField("query_timestamp", o.GetQueryTimestamp())
+}
+
+// SetQueryTime sets QueryTimestamp to t, keeping its offset and fractional
+// seconds.
+func (o *RecallRequest) SetQueryTime(t time.Time) {
+	o.SetQueryTimestamp(t.Format(time.RFC3339Nano))
+}
+
+// SortRecallResultsByTime sorts results by when they happened: by
+// OccurredStart, or MentionedAt for facts without one, oldest first.
+// Results with neither, or with a timestamp that cannot be parsed, keep
+// their relative order after the others.
+func SortRecallResultsByTime(results []RecallResult) {
+	times := make([]time.Time, len(results))
+	for i := range results {
+		times[i] = recallResultTime(&results[i])
+	}
+	sort.Stable(byTime{results, times})
+}
+
+func recallResultTime(r *RecallResult) time.Time {
+	if t, err := r.OccurredStartTime(); err == nil && !t.IsZero() {
+		return t
+	}
+	t, _ := r.MentionedAtTime()
+	return t
+}
+
+type byTime struct {
+	results []RecallResult
+	times   []time.Time
+}
+
+func (s byTime) Len() int { return len(s.results) }
+
+func (s byTime) Less(i, j int) bool {
+	ti, tj := s.times[i], s.times[j]
+	if ti.IsZero() || tj.IsZero() {
+		return !ti.IsZero() && tj.IsZero()
+	}
+	return ti.Before(tj)
+}
+
+func (s byTime) Swap(i, j int) {
+	s.results[i], s.results[j] = s.results[j], s.results[i]
+	s.times[i], s.times[j] = s.times[j], s.times[i]
+}
@@ -0,0 +1,89 @@
+package hindsight
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	utc := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024-01-15T10:30:00Z", utc},
+		{"2024-01-15T10:30:00+00:00", utc},
+		{"2024-01-15T12:30:00+02:00", utc},
+		{"2024-01-15T10:30:00", utc},
+		{"2024-01-15 10:30:00+00:00", utc},
+		{"2024-01-15T10:30:00.123456+00:00", utc.Add(123456 * time.Microsecond)},
+		{"2024-01-15T10:30", utc},
+		{"2024-01-15", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimestamp(tt.in)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseTimestamp(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if got, _ := ParseTimestamp("2024-01-15T12:30:00+02:00"); got.Format(time.RFC3339) != "2024-01-15T12:30:00+02:00" {
+		t.Errorf("offset not kept: %v", got)
+	}
+	if _, err := ParseTimestamp("last tuesday"); err == nil {
+		t.Error("ParseTimestamp accepted garbage")
+	}
+}
+
+func TestTimestampAccessors(t *testing.T) {
+	var r RecallResult
+	if got, err := r.OccurredStartTime(); err != nil || !got.IsZero() {
+		t.Fatalf("unset = %v, %v", got, err)
+	}
+	r.SetOccurredStart("yesterday")
+	_, err := r.OccurredStartTime()
+	var tsErr *TimestampError
+	if !errors.As(err, &tsErr) || tsErr.Field != "occurred_start" || tsErr.Value != "yesterday" {
+		t.Fatalf("err = %v", err)
+	}
+
+	req := NewRecallRequest("q")
+	at := time.Date(2024, 1, 15, 10, 30, 0, 5000, time.FixedZone("", -5*3600))
+	req.SetQueryTime(at)
+	if got, err := req.QueryTime(); err != nil || !got.Equal(at) {
+		t.Fatalf("QueryTime = %v, %v", got, err)
+	}
+}
+
+func TestSortRecallResultsByTime(t *testing.T) {
+	result := func(id, occurred, mentioned string) RecallResult {
+		r := NewRecallResult(id, id)
+		if occurred != "" {
+			r.SetOccurredStart(occurred)
+		}
+		if mentioned != "" {
+			r.SetMentionedAt(mentioned)
+		}
+		return *r
+	}
+	results := []RecallResult{
+		result("none", "", ""),
+		result("late", "2024-03-01T00:00:00Z", ""),
+		result("mentioned", "", "2024-02-01T00:00:00+00:00"),
+		// Earlier than "mentioned" once the offset is applied, though
+		// later as a string.
+		result("early", "2024-02-01T01:00:00+05:00", ""),
+		result("bad", "soon", ""),
+	}
+	SortRecallResultsByTime(results)
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Id)
+	}
+	want := []string{"early", "mentioned", "late", "none", "bad"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,431 @@
+package hindsight
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCertificatePinMismatch is returned when none of the server's
+// certificates match a pinned public key.
+var ErrCertificatePinMismatch = errors.New("hindsight: server certificate does not match any pinned public key")
+
+// TLSOption configures the TLS settings built by NewTLSConfig.
+type TLSOption func(*tlsOptions)
+
+type tlsOptions struct {
+	certFile, keyFile string
+	caFiles           []string
+	caPEM             [][]byte
+	pins              []string
+	serverName        string
+	minVersion        uint16
+	reloadInterval    time.Duration
+}
+
+// WithClientCertificate presents the certificate and key in the given PEM
+// files to the server for mutual TLS. The files are reloaded when they change
+// on disk.
+func WithClientCertificate(certFile, keyFile string) TLSOption {
+	return func(o *tlsOptions) {
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// WithCAFile trusts the CA certificates in the given PEM bundle instead of the
+// system roots. It may be repeated; the bundle is reloaded when it changes on
+// disk.
+func WithCAFile(path string) TLSOption {
+	return func(o *tlsOptions) {
+		o.caFiles = append(o.caFiles, path)
+	}
+}
+
+// WithCAPEM trusts the CA certificates in the given PEM data instead of the
+// system roots. It may be combined with WithCAFile.
+func WithCAPEM(pem []byte) TLSOption {
+	return func(o *tlsOptions) {
+		o.caPEM = append(o.caPEM, pem)
+	}
+}
+
+// WithPinnedPublicKeys requires the server's certificate chain to contain a
+// public key whose SHA-256 SubjectPublicKeyInfo digest matches one of pins.
+// Pins are base64-encoded, optionally prefixed with "sha256/", as printed by
+//
+//	openssl x509 -pubkey -noout -in cert.pem | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary | base64
+//
+// Pin at least one backup key so that certificate rotation does not lock out
+// the client.
+func WithPinnedPublicKeys(pins ...string) TLSOption {
+	return func(o *tlsOptions) {
+		o.pins = append(o.pins, pins...)
+	}
+}
+
+// WithServerName overrides the host name used to verify the server
+// certificate, for servers reached through an IP address or a local proxy.
+func WithServerName(name string) TLSOption {
+	return func(o *tlsOptions) {
+		o.serverName = name
+	}
+}
+
+// WithMinTLSVersion sets the minimum TLS version. Defaults to TLS 1.2.
+func WithMinTLSVersion(version uint16) TLSOption {
+	return func(o *tlsOptions) {
+		o.minVersion = version
+	}
+}
+
+// WithCertificateReloadInterval sets how often certificate and CA files are
+// checked for changes. Defaults to 30 seconds.
+func WithCertificateReloadInterval(d time.Duration) TLSOption {
+	return func(o *tlsOptions) {
+		o.reloadInterval = d
+	}
+}
+
+// NewTLSConfig builds a tls.Config for talking to a Hindsight server with
+// client certificates, private CAs and public key pinning. Files are read
+// immediately so that configuration errors surface at construction time.
+//
+// With a private CA, the server certificate is checked against the host name
+// crypto/tls reports for the connection. It reports none for a server reached
+// by IP address, so such connections fail unless WithServerName is given;
+// NewAPIClientWithTLS checks them against the dialed address instead.
+func NewTLSConfig(opts ...TLSOption) (*tls.Config, error) {
+	cfg, _, err := newTLSConfig(opts...)
+	return cfg, err
+}
+
+// newTLSConfig is NewTLSConfig, also returning the verifier behind
+// VerifyConnection (nil if there is none) so that a dialer can check the
+// certificate against the address it dialed.
+func newTLSConfig(opts ...TLSOption) (*tls.Config, *peerVerifier, error) {
+	o := tlsOptions{minVersion: tls.VersionTLS12, reloadInterval: 30 * time.Second}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cfg := &tls.Config{
+		MinVersion: o.minVersion,
+		ServerName: o.serverName,
+	}
+
+	if o.certFile != "" || o.keyFile != "" {
+		if o.certFile == "" || o.keyFile == "" {
+			return nil, nil, errors.New("hindsight: client certificate requires both a certificate and a key file")
+		}
+		kp := &keyPairReloader{certFile: o.certFile, keyFile: o.keyFile, interval: o.reloadInterval}
+		if _, err := kp.get(); err != nil {
+			return nil, nil, err
+		}
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return kp.get()
+		}
+	}
+
+	v := &peerVerifier{serverName: o.serverName, pins: make(map[string]bool, len(o.pins))}
+	if len(o.caFiles) > 0 || len(o.caPEM) > 0 {
+		v.roots = &caReloader{files: o.caFiles, pem: o.caPEM, interval: o.reloadInterval}
+		if _, err := v.roots.get(); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, p := range o.pins {
+		v.pins[strings.TrimPrefix(p, "sha256/")] = true
+	}
+
+	if v.roots == nil && len(v.pins) == 0 {
+		return cfg, nil, nil
+	}
+
+	if v.roots != nil {
+		// Verification against a reloadable pool has to happen in
+		// VerifyConnection, because tls.Config.RootCAs is fixed once the
+		// config is in use.
+		cfg.InsecureSkipVerify = true
+	}
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		return v.verify(cs, cs.ServerName)
+	}
+	return cfg, v, nil
+}
+
+// NewAPIClientWithTLS creates a new API client configured with a base URL, API
+// token, request timeout and TLS options. Use 0 for no timeout and an empty
+// token to send no Authorization header.
+//
+// Example:
+//
+//	client, err := hindsight.NewAPIClientWithTLS("https://hindsight.internal:8888", "", 30*time.Second,
+//		hindsight.WithClientCertificate("/etc/certs/tls.crt", "/etc/certs/tls.key"),
+//		hindsight.WithCAFile("/etc/certs/ca.crt"),
+//	)
+func NewAPIClientWithTLS(baseURL, token string, timeout time.Duration, opts ...TLSOption) (*APIClient, error) {
+	tlsConfig, verifier, err := newTLSConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if verifier != nil {
+		transport.DialTLSContext = verifier.dialTLS(tlsConfig)
+	}
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{
+		{URL: baseURL},
+	}
+	if token != "" {
+		cfg.AddDefaultHeader("Authorization", "Bearer "+token)
+	}
+	cfg.HTTPClient = &http.Client{Timeout: timeout, Transport: transport}
+	return NewAPIClient(cfg), nil
+}
+
+// peerVerifier checks server certificates against a reloadable CA pool and
+// public key pins.
+type peerVerifier struct {
+	roots      *caReloader
+	pins       map[string]bool
+	serverName string
+}
+
+// verify checks the connection's certificates for host, or for the
+// WithServerName override if one was given.
+func (v *peerVerifier) verify(cs tls.ConnectionState, host string) error {
+	if v.serverName != "" {
+		host = v.serverName
+	}
+	chains := cs.VerifiedChains
+	if v.roots != nil {
+		if host == "" {
+			return errors.New("hindsight: no host name to verify the server certificate against; use WithServerName")
+		}
+		pool, err := v.roots.get()
+		if err != nil {
+			return err
+		}
+		chains, err = verifyPeer(cs, pool, host)
+		if err != nil {
+			return err
+		}
+	}
+	if len(v.pins) > 0 {
+		return checkPins(cs, chains, v.pins)
+	}
+	return nil
+}
+
+// dialTLS returns a DialTLSContext function that verifies each connection
+// against the host it dialed, IP addresses included, which
+// tls.ConnectionState does not report.
+func (v *peerVerifier) dialTLS(cfg *tls.Config) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		c := cfg.Clone()
+		if c.ServerName == "" {
+			c.ServerName = host
+		}
+		c.VerifyConnection = func(cs tls.ConnectionState) error {
+			return v.verify(cs, host)
+		}
+		var d net.Dialer
+		raw, err := d.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		conn := tls.Client(raw, c)
+		if err := conn.HandshakeContext(ctx); err != nil {
+			raw.Close()
+			return nil, err
+		}
+		return conn, nil
+	}
+}
+
+// verifyPeer verifies the server's chain against roots for host, which may
+// be a DNS name or an IP address.
+func verifyPeer(cs tls.ConnectionState, roots *x509.CertPool, host string) ([][]*x509.Certificate, error) {
+	if len(cs.PeerCertificates) == 0 {
+		return nil, errors.New("hindsight: server presented no certificates")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	return cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       host,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+}
+
+// checkPins matches the pins against the verified chains only: a server
+// can send extra certificates that are not part of any chain, including a
+// pinned certificate it does not hold the key for. If verification was
+// skipped, only the leaf is checked.
+func checkPins(cs tls.ConnectionState, chains [][]*x509.Certificate, pins map[string]bool) error {
+	if len(chains) == 0 && len(cs.PeerCertificates) > 0 {
+		chains = [][]*x509.Certificate{cs.PeerCertificates[:1]}
+	}
+	for _, chain := range chains {
+		for _, cert := range chain {
+			if pins[PublicKeyPin(cert)] {
+				return nil
+			}
+		}
+	}
+	return ErrCertificatePinMismatch
+}
+
+// PublicKeyPin returns the base64-encoded SHA-256 digest of the certificate's
+// SubjectPublicKeyInfo, in the form accepted by WithPinnedPublicKeys.
+func PublicKeyPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// fileStamp records enough about a file to notice that it changed.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func stampFiles(paths ...string) ([]fileStamp, error) {
+	stamps := make([]fileStamp, len(paths))
+	for i, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		stamps[i] = fileStamp{info.ModTime(), info.Size()}
+	}
+	return stamps, nil
+}
+
+func sameStamps(a, b []fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].modTime.Equal(b[i].modTime) || a[i].size != b[i].size {
+			return false
+		}
+	}
+	return true
+}
+
+// keyPairReloader serves a client certificate, re-reading it when the files
+// change. A failed reload keeps serving the last good certificate.
+type keyPairReloader struct {
+	certFile, keyFile string
+	interval          time.Duration
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	stamps    []fileStamp
+	lastCheck time.Time
+}
+
+func (r *keyPairReloader) get() (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if r.cert != nil && now.Sub(r.lastCheck) < r.interval {
+		return r.cert, nil
+	}
+	r.lastCheck = now
+	stamps, err := stampFiles(r.certFile, r.keyFile)
+	if err == nil && r.cert != nil && sameStamps(stamps, r.stamps) {
+		return r.cert, nil
+	}
+	if err == nil {
+		var cert tls.Certificate
+		cert, err = tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err == nil {
+			r.cert = &cert
+			r.stamps = stamps
+			return r.cert, nil
+		}
+	}
+	if r.cert != nil {
+		return r.cert, nil
+	}
+	return nil, fmt.Errorf("hindsight: loading client certificate: %w", err)
+}
+
+// caReloader serves a CA pool, re-reading the bundles when they change. A
+// failed reload keeps serving the last good pool.
+type caReloader struct {
+	files    []string
+	pem      [][]byte
+	interval time.Duration
+
+	mu        sync.Mutex
+	pool      *x509.CertPool
+	stamps    []fileStamp
+	lastCheck time.Time
+}
+
+func (r *caReloader) get() (*x509.CertPool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if r.pool != nil && now.Sub(r.lastCheck) < r.interval {
+		return r.pool, nil
+	}
+	r.lastCheck = now
+	stamps, err := stampFiles(r.files...)
+	if err == nil && r.pool != nil && sameStamps(stamps, r.stamps) {
+		return r.pool, nil
+	}
+	if err == nil {
+		var pool *x509.CertPool
+		pool, err = r.load()
+		if err == nil {
+			r.pool = pool
+			r.stamps = stamps
+			return r.pool, nil
+		}
+	}
+	if r.pool != nil {
+		return r.pool, nil
+	}
+	return nil, fmt.Errorf("hindsight: loading CA certificates: %w", err)
+}
+
+func (r *caReloader) load() (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	for _, pem := range r.pem {
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in CA PEM data")
+		}
+	}
+	for _, path := range r.files {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", path)
+		}
+	}
+	return pool, nil
+}
@@ -0,0 +1,210 @@
+package hindsight
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, cn string, parent *testCert, isCA bool) *testCert {
+	t.Helper()
+	return newTestCertFor(t, cn, parent, isCA, []string{"localhost"}, []net.IP{net.ParseIP("127.0.0.1")})
+}
+
+func newTestCertFor(t *testing.T, cn string, parent *testCert, isCA bool, dnsNames []string, ips []net.IP) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:              dnsNames,
+		IPAddresses:           ips,
+	}
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCert(t, "test-ca", nil, true)
+	serverCert := newTestCert(t, "server", ca, false)
+	clientCert := newTestCert(t, "client", ca, false)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "client" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"api_version":"0.4.14","features":{"observations":true,"mcp":true,"worker":true,"bank_config_api":true,"file_upload_api":true}}`))
+	}))
+	pair, _ := tls.X509KeyPair(serverCert.certPEM, serverCert.keyPEM)
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{pair}, ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	srv.StartTLS()
+	defer srv.Close()
+
+	dir := t.TempDir()
+	certFile := writeFile(t, dir, "client.crt", clientCert.certPEM)
+	keyFile := writeFile(t, dir, "client.key", clientCert.keyPEM)
+	caFile := writeFile(t, dir, "ca.crt", ca.certPEM)
+
+	client, err := NewAPIClientWithTLS(srv.URL, "", 5*time.Second,
+		WithClientCertificate(certFile, keyFile),
+		WithCAFile(caFile),
+		WithPinnedPublicKeys("sha256/"+PublicKeyPin(ca.cert)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.MonitoringAPI.GetVersion(context.Background()).Execute(); err != nil {
+		t.Fatalf("mTLS request failed: %v", err)
+	}
+
+	pinned, err := NewAPIClientWithTLS(srv.URL, "", 5*time.Second,
+		WithClientCertificate(certFile, keyFile),
+		WithCAFile(caFile),
+		WithPinnedPublicKeys(PublicKeyPin(clientCert.cert)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = pinned.MonitoringAPI.GetVersion(context.Background()).Execute()
+	if !errors.Is(err, ErrCertificatePinMismatch) {
+		t.Fatalf("expected pin mismatch, got %v", err)
+	}
+
+	// A certificate appended after the leaf is not part of the verified
+	// chain, so pinning it must not let the connection through.
+	appended := newTestCert(t, "pinned", newTestCert(t, "pinned-ca", nil, true), false)
+	mitm := httptest.NewUnstartedServer(srv.Config.Handler)
+	mitm.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{serverCert.cert.Raw, appended.cert.Raw}, PrivateKey: serverCert.key}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	mitm.StartTLS()
+	defer mitm.Close()
+	spoofed, err := NewAPIClientWithTLS(mitm.URL, "", 5*time.Second,
+		WithClientCertificate(certFile, keyFile),
+		WithCAFile(caFile),
+		WithPinnedPublicKeys(PublicKeyPin(appended.cert)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = spoofed.MonitoringAPI.GetVersion(context.Background()).Execute()
+	if !errors.Is(err, ErrCertificatePinMismatch) {
+		t.Fatalf("expected pin mismatch for an appended certificate, got %v", err)
+	}
+
+	// A certificate from the trusted CA for another host is rejected when
+	// the server is reached by IP address.
+	evilCert := newTestCertFor(t, "evil", ca, false, []string{"evil.example"}, nil)
+	evil := httptest.NewUnstartedServer(srv.Config.Handler)
+	evilPair, _ := tls.X509KeyPair(evilCert.certPEM, evilCert.keyPEM)
+	evil.TLS = &tls.Config{Certificates: []tls.Certificate{evilPair}, ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	evil.StartTLS()
+	defer evil.Close()
+	misnamed, err := NewAPIClientWithTLS(evil.URL, "", 5*time.Second, WithClientCertificate(certFile, keyFile), WithCAPEM(ca.certPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := misnamed.MonitoringAPI.GetVersion(context.Background()).Execute(); err == nil || !strings.Contains(err.Error(), "127.0.0.1") {
+		t.Fatalf("expected a host name mismatch for 127.0.0.1, got %v", err)
+	}
+
+	untrusted := newTestCert(t, "other-ca", nil, true)
+	otherCA := writeFile(t, dir, "other-ca.crt", untrusted.certPEM)
+	rejected, err := NewAPIClientWithTLS(srv.URL, "", 5*time.Second, WithClientCertificate(certFile, keyFile), WithCAFile(otherCA))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := rejected.MonitoringAPI.GetVersion(context.Background()).Execute(); err == nil {
+		t.Fatal("expected verification failure against an untrusted CA")
+	}
+}
+
+func TestClientCertificateReload(t *testing.T) {
+	ca := newTestCert(t, "test-ca", nil, true)
+	first := newTestCert(t, "first", ca, false)
+	second := newTestCert(t, "second", ca, false)
+
+	dir := t.TempDir()
+	certFile := writeFile(t, dir, "tls.crt", first.certPEM)
+	keyFile := writeFile(t, dir, "tls.key", first.keyPEM)
+
+	cfg, err := NewTLSConfig(WithClientCertificate(certFile, keyFile), WithCertificateReloadInterval(time.Nanosecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := cfg.GetClientCertificate(nil)
+	if leaf, _ := x509.ParseCertificate(got.Certificate[0]); leaf.Subject.CommonName != "first" {
+		t.Fatalf("got %s, want first", leaf.Subject.CommonName)
+	}
+
+	writeFile(t, dir, "tls.crt", second.certPEM)
+	writeFile(t, dir, "tls.key", second.keyPEM)
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(certFile, later, later)
+	os.Chtimes(keyFile, later, later)
+
+	got, _ = cfg.GetClientCertificate(nil)
+	if leaf, _ := x509.ParseCertificate(got.Certificate[0]); leaf.Subject.CommonName != "second" {
+		t.Fatalf("got %s, want second", leaf.Subject.CommonName)
+	}
+}
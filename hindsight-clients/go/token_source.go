@@ -0,0 +1,269 @@
+package hindsight
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Token is a bearer credential returned by a TokenSource.
+type Token struct {
+	// AccessToken is the credential sent in the Authorization header.
+	AccessToken string
+	// TokenType is the Authorization scheme. Defaults to "Bearer".
+	TokenType string
+	// Expiry is when the token stops being valid. The zero value means the
+	// token does not expire.
+	Expiry time.Time
+}
+
+// tokenExpiryLeeway refreshes tokens slightly before they expire so that a
+// token is not used while in flight across its expiry.
+const tokenExpiryLeeway = 10 * time.Second
+
+// Valid reports whether t is non-empty and not about to expire.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(tokenExpiryLeeway).Before(t.Expiry)
+}
+
+func (t *Token) authorizationHeader() string {
+	tokenType := t.TokenType
+	if tokenType == "" {
+		tokenType = "Bearer"
+	}
+	return tokenType + " " + t.AccessToken
+}
+
+// TokenSource supplies the credential for a request. It is consulted on every
+// request, so implementations that fetch tokens remotely should cache them,
+// for example with ReuseTokenSource.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenInvalidator is implemented by token sources that cache tokens. After
+// the server rejects a token with 401 Unauthorized, InvalidateToken is called
+// before the source is consulted again for the single retry.
+type TokenInvalidator interface {
+	InvalidateToken(ctx context.Context)
+}
+
+// TokenSourceFunc adapts a function to the TokenSource interface. The context
+// is the request context, so the function can resolve per-tenant keys.
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource returns a TokenSource that always returns token.
+func StaticTokenSource(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (*Token, error) {
+		return &Token{AccessToken: token}, nil
+	})
+}
+
+// EnvTokenSource returns a TokenSource that reads the token from the
+// environment variable name on every request.
+func EnvTokenSource(name string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (*Token, error) {
+		v := strings.TrimSpace(os.Getenv(name))
+		if v == "" {
+			return nil, fmt.Errorf("hindsight: environment variable %s is not set", name)
+		}
+		return &Token{AccessToken: v}, nil
+	})
+}
+
+// FileTokenSource reads the token from a file, such as a mounted Kubernetes
+// secret. The file is checked for changes at most once per pollInterval (one
+// second if zero) and re-read when its size or modification time changes,
+// so rotated keys are picked up without rebuilding the client. Surrounding
+// whitespace is trimmed.
+func FileTokenSource(path string, pollInterval time.Duration) TokenSource {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	return &fileTokenSource{path: path, pollInterval: pollInterval}
+}
+
+type fileTokenSource struct {
+	path         string
+	pollInterval time.Duration
+
+	mu        sync.Mutex
+	token     *Token
+	modTime   time.Time
+	size      int64
+	lastCheck time.Time
+}
+
+func (s *fileTokenSource) Token(context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if s.token != nil && now.Sub(s.lastCheck) < s.pollInterval {
+		return s.token, nil
+	}
+	s.lastCheck = now
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("hindsight: reading token file: %w", err)
+	}
+	if s.token != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.token, nil
+	}
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("hindsight: reading token file: %w", err)
+	}
+	v := strings.TrimSpace(string(b))
+	if v == "" {
+		return nil, fmt.Errorf("hindsight: token file %s is empty", s.path)
+	}
+	s.token = &Token{AccessToken: v}
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return s.token, nil
+}
+
+// InvalidateToken forces the file to be re-read on the next request.
+func (s *fileTokenSource) InvalidateToken(context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = nil
+}
+
+// ReuseTokenSource caches the token returned by src until it expires or is
+// invalidated after a 401. If key is non-nil, tokens are cached separately
+// for each key it returns, which lets a per-tenant TokenSourceFunc be cached
+// without leaking credentials between tenants.
+func ReuseTokenSource(src TokenSource, key func(ctx context.Context) string) TokenSource {
+	return &reuseTokenSource{src: src, key: key, tokens: make(map[string]*Token)}
+}
+
+type reuseTokenSource struct {
+	src TokenSource
+	key func(ctx context.Context) string
+
+	mu     sync.Mutex
+	tokens map[string]*Token
+}
+
+func (s *reuseTokenSource) cacheKey(ctx context.Context) string {
+	if s.key == nil {
+		return ""
+	}
+	return s.key(ctx)
+}
+
+func (s *reuseTokenSource) Token(ctx context.Context) (*Token, error) {
+	key := s.cacheKey(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t := s.tokens[key]; t.Valid() {
+		return t, nil
+	}
+	t, err := s.src.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.tokens[key] = t
+	return t, nil
+}
+
+func (s *reuseTokenSource) InvalidateToken(ctx context.Context) {
+	key := s.cacheKey(ctx)
+	s.mu.Lock()
+	delete(s.tokens, key)
+	s.mu.Unlock()
+	if inv, ok := s.src.(TokenInvalidator); ok {
+		inv.InvalidateToken(ctx)
+	}
+}
+
+// SetTokenSource makes every request carry the credential returned by ts in
+// its Authorization header, replacing any Authorization default header. A
+// per-call Authorization(...) override on an API builder still takes
+// precedence. When the server responds 401 Unauthorized, the token is
+// invalidated and the request is retried once with a fresh token.
+//
+// Call SetTokenSource once, before NewAPIClient and after installing any
+// ResponseCache or SingleflightMiddleware.
+func (c *Configuration) SetTokenSource(ts TokenSource) {
+	header := make(map[string]string, len(c.DefaultHeader))
+	for k, v := range c.DefaultHeader {
+		if k != "Authorization" {
+			header[k] = v
+		}
+	}
+	c.DefaultHeader = header
+	c.Use(tokenSourceMiddleware(ts))
+}
+
+func tokenSourceMiddleware(ts TokenSource) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// Generated builders store per-call overrides under the
+			// lower-case "authorization" key. A token source installed
+			// later, as by Configuration.WithTokenSource, runs first and
+			// wins.
+			if _, ok := req.Header["authorization"]; ok || req.Context().Value(authorizedKey{}) != nil {
+				return next.RoundTrip(req)
+			}
+			ctx := req.Context()
+			if ctx.Value(credentialKeyedKey{}) != nil {
+				closeRequestBody(req)
+				return nil, errTokenSourceInsideCache
+			}
+			token, err := ts.Token(ctx)
+			if err != nil {
+				closeRequestBody(req)
+				return nil, err
+			}
+			resp, err := next.RoundTrip(withAuthorization(req, token))
+			if err != nil || resp.StatusCode != http.StatusUnauthorized {
+				return resp, err
+			}
+
+			inv, ok := ts.(TokenInvalidator)
+			if !ok || (req.Body != nil && req.GetBody == nil) {
+				return resp, nil
+			}
+			inv.InvalidateToken(ctx)
+			fresh, err := ts.Token(ctx)
+			if err != nil || fresh.AccessToken == token.AccessToken {
+				return resp, nil
+			}
+			retry := withAuthorization(req, fresh)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return resp, nil
+				}
+				retry.Body = body
+			}
+			resp.Body.Close()
+			return next.RoundTrip(retry)
+		})
+	}
+}
+
+// authorizedKey marks a request context whose Authorization header was set
+// by a token source.
+type authorizedKey struct{}
+
+func withAuthorization(req *http.Request, token *Token) *http.Request {
+	out := req.Clone(context.WithValue(req.Context(), authorizedKey{}, true))
+	out.Header.Set("Authorization", token.authorizationHeader())
+	return out
+}
@@ -0,0 +1,107 @@
+package hindsight
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenSourceRefreshesOn401(t *testing.T) {
+	var current atomic.Value
+	current.Store("old")
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results":[]}`))
+	}))
+	defer srv.Close()
+
+	var fetches int32
+	ts := ReuseTokenSource(TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		atomic.AddInt32(&fetches, 1)
+		return &Token{AccessToken: current.Load().(string), Expiry: time.Now().Add(time.Hour)}, nil
+	}), nil)
+
+	client := NewAPIClientWithTokenSource(srv.URL, ts)
+	ctx := context.Background()
+
+	// The cached token is reused until the key rotates.
+	current.Store("new")
+	if _, _, err := client.MemoryAPI.RecallMemories(ctx, "b").RecallRequest(RecallRequest{Query: "q"}).Execute(); err != nil {
+		t.Fatalf("recall: %v", err)
+	}
+	if fetches != 1 || calls != 1 {
+		t.Fatalf("fetches=%d calls=%d, want 1 and 1", fetches, calls)
+	}
+
+	// Force a stale cached token, then expect a single refresh and retry.
+	current.Store("old")
+	ts.(TokenInvalidator).InvalidateToken(ctx)
+	if _, _, err := client.MemoryAPI.RecallMemories(ctx, "b").RecallRequest(RecallRequest{Query: "q"}).Execute(); err == nil {
+		t.Fatal("expected 401 while the source keeps returning the rejected token")
+	}
+	current.Store("new")
+	calls = 0
+	if _, _, err := client.MemoryAPI.RecallMemories(ctx, "b").RecallRequest(RecallRequest{Query: "q"}).Execute(); err != nil {
+		t.Fatalf("recall after rotation: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 401 followed by one retry", calls)
+	}
+}
+
+func TestFileTokenSourcePicksUpRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ts := FileTokenSource(path, time.Nanosecond)
+	tok, err := ts.Token(context.Background())
+	if err != nil || tok.AccessToken != "first" {
+		t.Fatalf("got %v, %v", tok, err)
+	}
+
+	if err := os.WriteFile(path, []byte("second-key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(path, later, later)
+	tok, err = ts.Token(context.Background())
+	if err != nil || tok.AccessToken != "second-key" {
+		t.Fatalf("got %v, %v", tok, err)
+	}
+}
+
+func TestTokenSourcePerCallOverrideWins(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"banks":[]}`))
+	}))
+	defer srv.Close()
+
+	client := NewAPIClientWithTokenSource(srv.URL, StaticTokenSource("from-source"))
+	if _, _, err := client.BanksAPI.ListBanks(context.Background()).Authorization("Bearer explicit").Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got != "Bearer explicit" {
+		t.Fatalf("Authorization = %q, want per-call override", got)
+	}
+	if _, _, err := client.BanksAPI.ListBanks(context.Background()).Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got != "Bearer from-source" {
+		t.Fatalf("Authorization = %q, want token source value", got)
+	}
+}
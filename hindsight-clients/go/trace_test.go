@@ -37,7 +37,7 @@ func TestTraceResponse(t *testing.T) {
 		Query:     "What color is the sky?",
 		MaxTokens: PtrInt32(2048),
 		Trace:     PtrBool(true), // This was failing with ogen
-		Types:     []string{"world"},
+		Types:     []FactType{FactTypeWorld},
 	}
 
 	resp, httpResp, err := client.MemoryAPI.RecallMemories(ctx, "test_trace_bank").
@@ -0,0 +1,252 @@
+package hindsight
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// unixScheme is the URL scheme for servers reached through a Unix domain
+// socket, for example "unix:///run/hindsight/api.sock".
+const unixScheme = "unix"
+
+// ErrDaemonNotFound is returned by DiscoverDaemon when no port is left to
+// allocate to a profile.
+var ErrDaemonNotFound = errors.New("hindsight: local daemon not found")
+
+// UnixSocketMiddleware lets the client use "unix:///path/to.sock" server URLs.
+// Requests to such URLs are sent over the Unix domain socket; all other
+// requests pass through unchanged. The socket path is the longest prefix of
+// the URL path that is a socket on disk, so a base path may follow it, as in
+// "unix:///run/hindsight.sock/api".
+//
+// New, NewAPIClientWithToken, NewAPIClientWithTimeout and
+// NewAPIClientWithTokenSource install it automatically for unix:// base URLs.
+// Clients built with NewAPIClient must install it with Configuration.Use;
+// without it, requests to unix:// URLs fail with an error saying so.
+func UnixSocketMiddleware() Middleware {
+	u := &unixSocketTransport{
+		transports: make(map[string]*http.Transport),
+		sockets:    make(map[string]string),
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Scheme != unixScheme {
+				return next.RoundTrip(req)
+			}
+			return u.RoundTrip(req)
+		})
+	}
+}
+
+type unixSocketTransport struct {
+	mu         sync.Mutex
+	transports map[string]*http.Transport
+	sockets    map[string]string // URL path prefix -> socket path
+}
+
+func (u *unixSocketTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	socket, rest, err := u.splitSocketPath(req.URL.Path)
+	if err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	out := req.Clone(req.Context())
+	out.URL.Scheme = "http"
+	out.URL.Host = "unix"
+	out.URL.Path = rest
+	out.URL.RawPath = ""
+	if raw := req.URL.EscapedPath(); strings.HasPrefix(raw, socket) {
+		out.URL.RawPath = strings.TrimPrefix(raw, socket)
+		if out.URL.EscapedPath() != out.URL.RawPath {
+			out.URL.RawPath = ""
+		}
+	}
+	out.Host = "unix"
+	return u.transport(socket).RoundTrip(out)
+}
+
+// splitSocketPath separates the socket file from the HTTP path that follows it.
+func (u *unixSocketTransport) splitSocketPath(path string) (socket, rest string, err error) {
+	u.mu.Lock()
+	for prefix, sock := range u.sockets {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			u.mu.Unlock()
+			return sock, strings.TrimPrefix(path, prefix), nil
+		}
+	}
+	u.mu.Unlock()
+
+	candidate := path
+	for candidate != "/" && candidate != "." && candidate != "" {
+		if info, statErr := os.Stat(candidate); statErr == nil && info.Mode()&os.ModeSocket != 0 {
+			u.mu.Lock()
+			u.sockets[candidate] = candidate
+			u.mu.Unlock()
+			rest = strings.TrimPrefix(path, candidate)
+			if rest == "" {
+				rest = "/"
+			}
+			return candidate, rest, nil
+		}
+		candidate = filepath.Dir(candidate)
+	}
+	return "", "", fmt.Errorf("hindsight: no Unix socket found in path %q", path)
+}
+
+func (u *unixSocketTransport) transport(socket string) *http.Transport {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if t, ok := u.transports[socket]; ok {
+		return t
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socket)
+	}
+	u.transports[socket] = t
+	return t
+}
+
+func isUnixSocketURL(baseURL string) bool {
+	return strings.HasPrefix(baseURL, unixScheme+"://")
+}
+
+// unixSocketError explains the error net/http reports for a unix:// request
+// that reached a transport without UnixSocketMiddleware.
+func unixSocketError(req *http.Request, err error) error {
+	if req.URL.Scheme != unixScheme || !strings.Contains(err.Error(), "unsupported protocol scheme") {
+		return err
+	}
+	return fmt.Errorf("hindsight: unix:// server URLs need UnixSocketMiddleware; install it with Configuration.Use: %w", err)
+}
+
+// DaemonEndpoint describes how to reach a local hindsight-embed daemon.
+type DaemonEndpoint struct {
+	// Profile is the resolved hindsight-embed profile; empty for the default profile.
+	Profile string
+	// URL is the server URL, http://127.0.0.1:<port>.
+	URL string
+}
+
+// DiscoverDaemon locates the hindsight-embed daemon for profile the same way
+// as the Python profile manager under ~/.hindsight. As in hindsight-embed,
+// HINDSIGHT_EMBED_PROFILE takes precedence over profile; an empty profile is
+// resolved from the active_profile file, then the default profile.
+//
+// The default profile listens on port 8888. A named profile listens on the
+// port recorded in profiles/metadata.json or, if it has none, the port the
+// profile manager would allocate to it.
+func DiscoverDaemon(profile string) (*DaemonEndpoint, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return discoverDaemon(filepath.Join(home, ".hindsight"), profile)
+}
+
+// Ports used by hindsight-embed's profile_manager.py.
+const (
+	defaultDaemonPort = 8888
+	profilePortBase   = 8889
+	profilePortRange  = 1000
+)
+
+func discoverDaemon(configDir, profile string) (*DaemonEndpoint, error) {
+	if env := os.Getenv("HINDSIGHT_EMBED_PROFILE"); env != "" {
+		profile = env
+	}
+	if profile == "" {
+		if b, err := os.ReadFile(filepath.Join(configDir, "active_profile")); err == nil {
+			profile = strings.TrimSpace(string(b))
+		}
+	}
+	if profile == "" {
+		return &DaemonEndpoint{URL: daemonURL(defaultDaemonPort)}, nil
+	}
+	port, err := profilePort(configDir, profile)
+	if err != nil {
+		return nil, err
+	}
+	return &DaemonEndpoint{Profile: profile, URL: daemonURL(port)}, nil
+}
+
+func daemonURL(port int) string {
+	return "http://127.0.0.1:" + strconv.Itoa(port)
+}
+
+// profilePort returns the port of a named profile as
+// ProfileManager.resolve_profile_paths does: the port in metadata.json, or
+// else the one _allocate_port derives from the name. Like the profile
+// manager, it treats a missing or unreadable metadata file as empty.
+func profilePort(configDir, profile string) (int, error) {
+	var metadata struct {
+		Profiles map[string]struct {
+			Port int `json:"port"`
+		} `json:"profiles"`
+	}
+	if b, err := os.ReadFile(filepath.Join(configDir, "profiles", "metadata.json")); err == nil {
+		if json.Unmarshal(b, &metadata) != nil {
+			metadata.Profiles = nil
+		}
+	}
+	if p := metadata.Profiles[profile]; p.Port != 0 {
+		return p.Port, nil
+	}
+
+	allocated := make(map[int]bool, len(metadata.Profiles))
+	for _, p := range metadata.Profiles {
+		if p.Port != 0 {
+			allocated[p.Port] = true
+		}
+	}
+	sum := sha256.Sum256([]byte(profile))
+	offset := int(new(big.Int).Mod(new(big.Int).SetBytes(sum[:]), big.NewInt(profilePortRange)).Int64())
+	port := profilePortBase + offset
+	attempt := 0
+	for allocated[port] && attempt < profilePortRange {
+		port = profilePortBase + (offset+attempt)%profilePortRange
+		attempt++
+	}
+	if attempt >= profilePortRange {
+		for p := profilePortBase; p < profilePortBase+profilePortRange; p++ {
+			if !allocated[p] {
+				return p, nil
+			}
+		}
+		return 0, fmt.Errorf("%w: no port available for profile %q", ErrDaemonNotFound, profile)
+	}
+	return port, nil
+}
+
+// NewAPIClientForDaemon creates a new API client for the local hindsight-embed
+// daemon of profile, discovered with DiscoverDaemon.
+//
+// Example:
+//
+//	client, err := hindsight.NewAPIClientForDaemon("")
+//	resp, _, err := client.MemoryAPI.RecallMemories(ctx, bankID).RecallRequest(req).Execute()
+func NewAPIClientForDaemon(profile string) (*APIClient, error) {
+	endpoint, err := DiscoverDaemon(profile)
+	if err != nil {
+		return nil, err
+	}
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{
+		{URL: endpoint.URL, Description: "hindsight-embed daemon"},
+	}
+	return NewAPIClient(cfg), nil
+}
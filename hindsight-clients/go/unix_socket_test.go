@@ -0,0 +1,103 @@
+package hindsight
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func serveUnixSocket(t *testing.T, path string, handler http.Handler) {
+	t.Helper()
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	srv := &http.Server{Handler: handler}
+	go srv.Serve(ln)
+	t.Cleanup(func() { srv.Close() })
+}
+
+func shortTempDir(t *testing.T) string {
+	t.Helper()
+	// Socket paths are limited to ~104 bytes, which t.TempDir can exceed.
+	dir, err := os.MkdirTemp("", "hs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestUnixSocketServerURL(t *testing.T) {
+	dir := shortTempDir(t)
+	socket := filepath.Join(dir, "api.sock")
+	var gotPath string
+	serveUnixSocket(t, socket, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"banks":[]}`))
+	}))
+
+	client := NewAPIClientWithToken("unix://"+socket, "token")
+	if _, _, err := client.BanksAPI.ListBanks(context.Background()).Execute(); err != nil {
+		t.Fatalf("request over unix socket failed: %v", err)
+	}
+	if gotPath != "/v1/default/banks" {
+		t.Fatalf("path = %q", gotPath)
+	}
+
+	cfg := NewConfiguration()
+	cfg.Servers = ServerConfigurations{{URL: "unix://" + socket + "/base"}}
+	_, _, err := NewAPIClient(cfg).BanksAPI.ListBanks(context.Background()).Execute()
+	if err == nil || !strings.Contains(err.Error(), "UnixSocketMiddleware") {
+		t.Fatalf("request without UnixSocketMiddleware: %v", err)
+	}
+	cfg.Use(UnixSocketMiddleware())
+	if _, _, err := NewAPIClient(cfg).BanksAPI.ListBanks(context.Background()).Execute(); err != nil {
+		t.Fatalf("request with base path failed: %v", err)
+	}
+	if gotPath != "/base/v1/default/banks" {
+		t.Fatalf("path = %q", gotPath)
+	}
+}
+
+func TestDiscoverDaemon(t *testing.T) {
+	t.Setenv("HINDSIGHT_EMBED_PROFILE", "")
+	dir := shortTempDir(t)
+	os.MkdirAll(filepath.Join(dir, "profiles"), 0o755)
+	os.WriteFile(filepath.Join(dir, "profiles", "metadata.json"), []byte(`{"profiles":{"work":{"port":9001}}}`), 0o644)
+
+	ep, err := discoverDaemon(dir, "")
+	if err != nil || ep.URL != "http://127.0.0.1:8888" {
+		t.Fatalf("default profile: %+v, %v", ep, err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "active_profile"), []byte("work\n"), 0o644)
+	ep, err = discoverDaemon(dir, "")
+	if err != nil || ep.Profile != "work" || ep.URL != "http://127.0.0.1:9001" {
+		t.Fatalf("active profile: %+v, %v", ep, err)
+	}
+
+	// A profile without a port gets the one hindsight-embed derives from its
+	// name, skipping ports taken by other profiles.
+	ep, err = discoverDaemon(dir, "research")
+	if err != nil || ep.URL != "http://127.0.0.1:8913" {
+		t.Fatalf("unrecorded profile: %+v, %v", ep, err)
+	}
+	os.WriteFile(filepath.Join(dir, "profiles", "metadata.json"), []byte(`{"profiles":{"work":{"port":9486}}}`), 0o644)
+	ep, err = discoverDaemon(dir, "missing")
+	if err != nil || ep.URL != "http://127.0.0.1:9487" {
+		t.Fatalf("colliding profile: %+v, %v", ep, err)
+	}
+
+	// HINDSIGHT_EMBED_PROFILE overrides an explicit profile.
+	t.Setenv("HINDSIGHT_EMBED_PROFILE", "work")
+	ep, err = discoverDaemon(dir, "research")
+	if err != nil || ep.Profile != "work" || ep.URL != "http://127.0.0.1:9486" {
+		t.Fatalf("environment profile: %+v, %v", ep, err)
+	}
+}
@@ -42,292 +42,52 @@ func PtrString(v string) *string { return &v }
 // PtrTime is helper routine that returns a pointer to given Time value.
 func PtrTime(v time.Time) *time.Time { return &v }
 
-type NullableBool struct {
-	value *bool
-	isSet bool
-}
-
-func (v NullableBool) Get() *bool {
-	return v.value
-}
-
-func (v *NullableBool) Set(val *bool) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableBool) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableBool) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableBool = Nullable[bool]
 
 func NewNullableBool(val *bool) *NullableBool {
-	return &NullableBool{value: val, isSet: true}
-}
-
-func (v NullableBool) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableBool) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
-type NullableInt struct {
-	value *int
-	isSet bool
-}
-
-func (v NullableInt) Get() *int {
-	return v.value
-}
-
-func (v *NullableInt) Set(val *int) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableInt) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableInt) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableInt = Nullable[int]
 
 func NewNullableInt(val *int) *NullableInt {
-	return &NullableInt{value: val, isSet: true}
-}
-
-func (v NullableInt) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableInt) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
-type NullableInt32 struct {
-	value *int32
-	isSet bool
-}
-
-func (v NullableInt32) Get() *int32 {
-	return v.value
-}
-
-func (v *NullableInt32) Set(val *int32) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableInt32) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableInt32) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableInt32 = Nullable[int32]
 
 func NewNullableInt32(val *int32) *NullableInt32 {
-	return &NullableInt32{value: val, isSet: true}
-}
-
-func (v NullableInt32) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableInt32) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
-type NullableInt64 struct {
-	value *int64
-	isSet bool
-}
-
-func (v NullableInt64) Get() *int64 {
-	return v.value
-}
-
-func (v *NullableInt64) Set(val *int64) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableInt64) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableInt64) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableInt64 = Nullable[int64]
 
 func NewNullableInt64(val *int64) *NullableInt64 {
-	return &NullableInt64{value: val, isSet: true}
-}
-
-func (v NullableInt64) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableInt64) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
-type NullableFloat32 struct {
-	value *float32
-	isSet bool
-}
-
-func (v NullableFloat32) Get() *float32 {
-	return v.value
-}
-
-func (v *NullableFloat32) Set(val *float32) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableFloat32) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableFloat32) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableFloat32 = Nullable[float32]
 
 func NewNullableFloat32(val *float32) *NullableFloat32 {
-	return &NullableFloat32{value: val, isSet: true}
-}
-
-func (v NullableFloat32) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableFloat32) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
-type NullableFloat64 struct {
-	value *float64
-	isSet bool
-}
-
-func (v NullableFloat64) Get() *float64 {
-	return v.value
-}
-
-func (v *NullableFloat64) Set(val *float64) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableFloat64) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableFloat64) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableFloat64 = Nullable[float64]
 
 func NewNullableFloat64(val *float64) *NullableFloat64 {
-	return &NullableFloat64{value: val, isSet: true}
-}
-
-func (v NullableFloat64) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableFloat64) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
-type NullableString struct {
-	value *string
-	isSet bool
-}
-
-func (v NullableString) Get() *string {
-	return v.value
-}
-
-func (v *NullableString) Set(val *string) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableString) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableString) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableString = Nullable[string]
 
 func NewNullableString(val *string) *NullableString {
-	return &NullableString{value: val, isSet: true}
-}
-
-func (v NullableString) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableString) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
-type NullableTime struct {
-	value *time.Time
-	isSet bool
-}
-
-func (v NullableTime) Get() *time.Time {
-	return v.value
-}
-
-func (v *NullableTime) Set(val *time.Time) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableTime) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableTime) Unset() {
-	v.value = nil
-	v.isSet = false
-}
+type NullableTime = Nullable[time.Time]
 
 func NewNullableTime(val *time.Time) *NullableTime {
-	return &NullableTime{value: val, isSet: true}
-}
-
-func (v NullableTime) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableTime) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
+	return NewNullable(val)
 }
 
 // IsNil checks if an input is nil
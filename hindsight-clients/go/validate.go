@@ -0,0 +1,246 @@
+package hindsight
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Validate checks the request against the constraints the server enforces,
+// so that invalid arguments (for example from an LLM tool call) fail before
+// a round trip. It returns nil or a *GenericOpenAPIError whose Model is an
+// HTTPValidationError, the same shape as a 422 response, with field paths
+// starting at "body" as the server reports them.
+//
+// Requests are validated automatically before they are sent when
+// Configuration.ValidateRequests is set, as WithRequestValidation does.
+func (o RecallRequest) Validate() error {
+	v := newValidator()
+	v.notEmpty(o.Query, "query")
+	v.budget(o.Budget, "budget")
+	v.tagsMatch(o.TagsMatch, "tags_match")
+	return v.err()
+}
+
+// Validate checks the request; see RecallRequest.Validate.
+func (o ReflectRequest) Validate() error {
+	v := newValidator()
+	v.notEmpty(o.Query, "query")
+	v.budget(o.Budget, "budget")
+	v.tagsMatch(o.TagsMatch, "tags_match")
+	return v.err()
+}
+
+// Validate checks the request; see RecallRequest.Validate.
+func (o RetainRequest) Validate() error {
+	v := newValidator()
+	for i, item := range o.Items {
+		item.validate(v.at("items", i))
+	}
+	return v.err()
+}
+
+// Validate checks the item; see RecallRequest.Validate.
+func (o MemoryItem) Validate() error {
+	v := newValidator()
+	o.validate(v)
+	return v.err()
+}
+
+func (o MemoryItem) validate(v *validator) {
+	v.notEmpty(o.Content, "content")
+}
+
+// Validate checks the traits; see RecallRequest.Validate.
+func (o DispositionTraits) Validate() error {
+	v := newValidator()
+	o.validate(v)
+	return v.err()
+}
+
+func (o DispositionTraits) validate(v *validator) {
+	v.between(o.Skepticism, 1, 5, "skepticism")
+	v.between(o.Literalism, 1, 5, "literalism")
+	v.between(o.Empathy, 1, 5, "empathy")
+}
+
+// Validate checks the request; see RecallRequest.Validate.
+func (o CreateBankRequest) Validate() error {
+	v := newValidator()
+	if d := o.Disposition.Get(); d != nil {
+		d.validate(v.at("disposition"))
+	}
+	traits := []struct {
+		field string
+		value NullableInt32
+	}{
+		{"disposition_skepticism", o.DispositionSkepticism},
+		{"disposition_literalism", o.DispositionLiteralism},
+		{"disposition_empathy", o.DispositionEmpathy},
+	}
+	for _, trait := range traits {
+		if t := trait.value.Get(); t != nil {
+			v.between(*t, 1, 5, trait.field)
+		}
+	}
+	return v.err()
+}
+
+// Validate checks the request; see RecallRequest.Validate.
+func (o UpdateDispositionRequest) Validate() error {
+	v := newValidator()
+	o.Disposition.validate(v.at("disposition"))
+	return v.err()
+}
+
+// Validate checks the request; see RecallRequest.Validate.
+func (o CreateMentalModelRequest) Validate() error {
+	v := newValidator()
+	if o.MaxTokens != nil {
+		v.between(*o.MaxTokens, 256, 8192, "max_tokens")
+	}
+	return v.err()
+}
+
+// Validate checks the request; see RecallRequest.Validate.
+func (o UpdateMentalModelRequest) Validate() error {
+	v := newValidator()
+	if t := o.MaxTokens.Get(); t != nil {
+		v.between(*t, 256, 8192, "max_tokens")
+	}
+	return v.err()
+}
+
+// Validate checks the request; see RecallRequest.Validate. The server sets
+// no constraints on it beyond its required fields.
+func (o CreateDirectiveRequest) Validate() error { return nil }
+
+// Validate checks the request; see RecallRequest.Validate. The server sets
+// no constraints on it.
+func (o UpdateDirectiveRequest) Validate() error { return nil }
+
+// Validate checks the request; see RecallRequest.Validate. The server sets
+// no constraints on it beyond its required fields.
+func (o AddBackgroundRequest) Validate() error { return nil }
+
+// Validate checks the request; see RecallRequest.Validate. The server
+// checks the individual configuration keys.
+func (o BankConfigUpdate) Validate() error { return nil }
+
+// validateBody validates a request body that has a Validate method.
+func validateBody(body interface{}) error {
+	if v, ok := body.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// validator collects validation errors under a field path.
+type validator struct {
+	loc    []ValidationErrorLocInner
+	errors *[]ValidationError
+}
+
+func newValidator() *validator {
+	body := "body"
+	return &validator{
+		loc:    []ValidationErrorLocInner{{String: &body}},
+		errors: new([]ValidationError),
+	}
+}
+
+// at returns a validator for the nested field path. Path elements are
+// field names (strings) or list indexes (ints).
+func (v *validator) at(path ...interface{}) *validator {
+	loc := append([]ValidationErrorLocInner(nil), v.loc...)
+	for _, p := range path {
+		switch p := p.(type) {
+		case string:
+			loc = append(loc, ValidationErrorLocInner{String: &p})
+		case int:
+			i := int32(p)
+			loc = append(loc, ValidationErrorLocInner{Int32: &i})
+		}
+	}
+	return &validator{loc: loc, errors: v.errors}
+}
+
+func (v *validator) add(field, msg, typ string) {
+	loc := v.at(field).loc
+	*v.errors = append(*v.errors, ValidationError{Loc: loc, Msg: msg, Type: typ})
+}
+
+func (v *validator) notEmpty(s, field string) {
+	if s == "" {
+		v.add(field, "String should have at least 1 character", "string_too_short")
+	}
+}
+
+func (v *validator) between(n, min, max int32, field string) {
+	switch {
+	case n < min:
+		v.add(field, fmt.Sprintf("Input should be greater than or equal to %d", min), "greater_than_equal")
+	case n > max:
+		v.add(field, fmt.Sprintf("Input should be less than or equal to %d", max), "less_than_equal")
+	}
+}
+
+func (v *validator) budget(b *Budget, field string) {
+	if b != nil && !b.IsValid() {
+		v.add(field, "Input should be 'low', 'mid' or 'high'", "enum")
+	}
+}
+
+func (v *validator) tagsMatch(m *TagsMatch, field string) {
+	if m != nil && !m.IsValid() {
+		v.add(field, "Input should be 'any', 'all', 'any_strict' or 'all_strict'", "literal_error")
+	}
+}
+
+func (v *validator) err() error {
+	if len(*v.errors) == 0 {
+		return nil
+	}
+	model := HTTPValidationError{Detail: *v.errors}
+	body, _ := json.Marshal(model)
+	msgs := make([]string, len(model.Detail))
+	for i, e := range model.Detail {
+		msgs[i] = validationPath(e.Loc) + ": " + e.Msg
+	}
+	return &GenericOpenAPIError{
+		body:  body,
+		error: "hindsight: invalid request: " + strings.Join(msgs, "; "),
+		model: model,
+	}
+}
+
+// validationPath formats a location such as ["body", "items", 0, "content"]
+// as "body.items[0].content".
+func validationPath(loc []ValidationErrorLocInner) string {
+	var b strings.Builder
+	for _, l := range loc {
+		switch {
+		case l.Int32 != nil:
+			b.WriteString("[" + strconv.Itoa(int(*l.Int32)) + "]")
+		case l.String != nil:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(*l.String)
+		}
+	}
+	return b.String()
+}
+
+// isValidationError reports whether err carries an HTTPValidationError,
+// whether from a 422 response or from client-side validation.
+func isValidationError(err error) bool {
+	var apiErr *GenericOpenAPIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	_, ok := apiErr.Model().(HTTPValidationError)
+	return ok
+}
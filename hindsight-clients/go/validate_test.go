@@ -0,0 +1,76 @@
+package hindsight
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	recall := NewRecallRequest("")
+	recall.Budget = Budget("extreme").Ptr()
+	recall.SetTagsMatch("some")
+	err := recall.Validate()
+	var apiErr *GenericOpenAPIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Validate = %v", err)
+	}
+	model, ok := apiErr.Model().(HTTPValidationError)
+	if !ok || len(model.Detail) != 3 {
+		t.Fatalf("model = %#v", apiErr.Model())
+	}
+	var paths []string
+	for _, d := range model.Detail {
+		paths = append(paths, validationPath(d.Loc))
+	}
+	if got := strings.Join(paths, ","); got != "body.query,body.budget,body.tags_match" {
+		t.Fatalf("paths = %s", got)
+	}
+
+	retain := NewRetainRequest([]MemoryItem{*NewMemoryItem("Alice prefers tea"), *NewMemoryItem("")})
+	err = retain.Validate()
+	if err == nil || !strings.Contains(err.Error(), "body.items[1].content") {
+		t.Fatalf("Validate = %v", err)
+	}
+	if body := string(err.(*GenericOpenAPIError).Body()); !strings.Contains(body, `"loc":["body","items",1,"content"]`) {
+		t.Fatalf("body = %s", body)
+	}
+
+	bank := NewCreateBankRequest()
+	bank.SetDisposition(*NewDispositionTraits(3, 0, 6))
+	bank.SetDispositionSkepticism(5)
+	if err := bank.Validate(); err == nil || strings.Count(err.Error(), "body.disposition.") != 2 {
+		t.Fatalf("Validate = %v", err)
+	}
+
+	valid := NewRecallRequest("tea")
+	valid.Budget = MID.Ptr()
+	valid.SetTagsMatch("any_strict")
+	if err := valid.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRequestValidationBeforeExecute(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer srv.Close()
+
+	client, err := New(WithBaseURL(srv.URL), WithRequestValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Bank("agent").Recall(context.Background(), "")
+	if !errors.Is(err, ErrInvalidRequest) || calls != 0 {
+		t.Fatalf("Recall err = %v after %d calls", err, calls)
+	}
+	_, err = client.Bank("agent").Retain(context.Background(), *NewMemoryItem(""))
+	if !errors.Is(err, ErrInvalidRequest) || calls != 0 {
+		t.Fatalf("Retain err = %v after %d calls", err, calls)
+	}
+}
@@ -353,11 +353,72 @@ else
     # Save maintained files to temp
     TEMP_DIR=$(mktemp -d)
     echo "Preserving maintained files..."
-    [ -f "README.md" ] && cp README.md "$TEMP_DIR/"
-    [ -f "integration_test.go" ] && cp integration_test.go "$TEMP_DIR/"
-    [ -f "null_test.go" ] && cp null_test.go "$TEMP_DIR/"
-    [ -f "trace_test.go" ] && cp trace_test.go "$TEMP_DIR/"
-    [ -f "hindsight_client.go" ] && cp hindsight_client.go "$TEMP_DIR/"
+    GO_MAINTAINED_FILES=(
+        README.md
+        integration_test.go
+        null_test.go
+        trace_test.go
+        hindsight_client.go
+        operations.go
+        middleware.go
+        circuit_breaker.go
+        circuit_breaker_test.go
+        rate_limit.go
+        rate_limit_test.go
+        token_source.go
+        token_source_test.go
+        tenant.go
+        tenant_test.go
+        tls.go
+        tls_test.go
+        unix_socket.go
+        unix_socket_test.go
+        launcher/launcher.go
+        launcher/launcher_test.go
+        features.go
+        features_test.go
+        metrics.go
+        metrics_test.go
+        bank.go
+        bank_test.go
+        retry.go
+        options.go
+        options_test.go
+        nullable.go
+        nullable_test.go
+        codec.go
+        codec_test.go
+        stream.go
+        stream_test.go
+        compress.go
+        compress_test.go
+        cache.go
+        cache_test.go
+        singleflight.go
+        singleflight_test.go
+        hedge.go
+        hedge_test.go
+        timeout.go
+        timeout_test.go
+        requestid.go
+        requestid_test.go
+        derive.go
+        derive_test.go
+        validate.go
+        validate_test.go
+        enums.go
+        enums_test.go
+        timestamps.go
+        timestamps_test.go
+        bankconfig.go
+        bankconfig_test.go
+    )
+    for f in "${GO_MAINTAINED_FILES[@]}"; do
+        if [ -f "$f" ]; then
+            mkdir -p "$TEMP_DIR/$(dirname "$f")"
+            cp "$f" "$TEMP_DIR/$f"
+        fi
+    done
 
     # Remove old generated files
     echo "Removing old generated code..."
@@ -387,11 +448,12 @@ else
 
     # Restore maintained files from temp
     echo "Restoring maintained files..."
-    [ -f "$TEMP_DIR/README.md" ] && mv "$TEMP_DIR/README.md" .
-    [ -f "$TEMP_DIR/integration_test.go" ] && mv "$TEMP_DIR/integration_test.go" .
-    [ -f "$TEMP_DIR/null_test.go" ] && mv "$TEMP_DIR/null_test.go" .
-    [ -f "$TEMP_DIR/trace_test.go" ] && mv "$TEMP_DIR/trace_test.go" .
-    [ -f "$TEMP_DIR/hindsight_client.go" ] && mv "$TEMP_DIR/hindsight_client.go" .
+    for f in "${GO_MAINTAINED_FILES[@]}"; do
+        if [ -f "$TEMP_DIR/$f" ]; then
+            mkdir -p "$(dirname "$f")"
+            mv "$TEMP_DIR/$f" "$f"
+        fi
+    done
     rm -rf "$TEMP_DIR"
 
     # Fix known generator issue: api_files.go uses os.File but generator omits "os" import
@@ -401,6 +463,75 @@ else
         rm -f api_files.go.bak
     fi
 
+    # Replace the generator's per-type Nullable structs with aliases of the
+    # generic Nullable[T] defined in the maintained nullable.go
+    echo "Patching Nullable types to use the generic Nullable[T]..."
+    perl -0pi -e 's/type Nullable(\w+) struct \{\n\tvalue \*(\S+)\n\tisSet bool\n\}\n.*?func \(v \*Nullable\1\) UnmarshalJSON\(src \[\]byte\) error \{\n\tv\.isSet = true\n\treturn json\.Unmarshal\(src, &v\.value\)\n\}\n/type Nullable$1 = Nullable[$2]\n\nfunc NewNullable$1(val *$2) *Nullable$1 {\n\treturn NewNullable(val)\n}\n/sg' utils.go model_*.go
+
+    # Encode models directly from their fields and check required properties
+    # with a key scan instead of round-tripping through ToMap and a generic
+    # map (see the maintained codec.go)
+    echo "Patching model serialization to skip the ToMap round-trip..."
+    perl -0pi -e 's/(func \(o \w+\) MarshalJSON\(\) \(\[\]byte, error\) \{\n)\ttoSerialize,err := o\.ToMap\(\)\n\tif err != nil \{\n\t\treturn \[\]byte\{\}, err\n\t\}\n\treturn json\.Marshal\(toSerialize\)\n/$1\treturn marshalModel(o)\n/g; s/\t\/\/ by unmarshalling the object into a generic map with string keys and checking\n\t\/\/ that every required field exists as a key in the generic map\.\n/\t\/\/ by scanning its top-level keys.\n/g; s/\tallProperties := make\(map\[string\]interface\{\}\)\n\n\terr = json\.Unmarshal\(data, &allProperties\)\n\n\tif err != nil \{\n\t\treturn err;\n\t\}\n\n\tfor _, requiredProperty := range\(requiredProperties\) \{\n\t\tif _, exists := allProperties\[requiredProperty\]; !exists \{\n\t\t\treturn fmt\.Errorf\("no value given for required property %v", requiredProperty\)\n\t\t\}\n\t\}\n/\terr = checkRequiredProperties(data, requiredProperties)\n\tif err != nil {\n\t\treturn err\n\t}\n/g; s/\t"fmt"\n// unless /\bfmt\./; s/\t"encoding\/json"\n// unless /\bjson\./' model_*.go
+
+    # Decode gzip responses in callAPI whatever HTTPClient is configured
+    # (see the maintained compress.go)
+    echo "Patching callAPI to decode compressed responses..."
+    perl -0pi -e 's/(\tresp, err := c\.cfg\.HTTPClient\.Do\(request\)\n\tif err != nil \{\n\t\treturn resp, err\n\t\}\n)/$1\tdecodeContentEncoding(resp)\n/' client.go
+
+    # Send an X-Request-ID with every call and record it in errors
+    # (see the maintained requestid.go)
+    echo "Patching request IDs into callAPI and GenericOpenAPIError..."
+    perl -0pi -e 's/(func \(c \*APIClient\) callAPI\(request \*http\.Request\) \(\*http\.Response, error\) \{\n)/$1\trequest = setRequestID(request)\n/; s/(\tresp, err := c\.cfg\.HTTPClient\.Do\(request\)\n\tif err != nil \{\n\t\treturn resp, )err\n/$1withRequestID(request, err)\n/; s/(type GenericOpenAPIError struct \{\n\tbody  \[\]byte\n\terror string\n\tmodel interface\{\}\n)/$1\trequestID string\n/; s/(func \(e GenericOpenAPIError\) Error\(\) string \{\n\treturn e\.error)\n/$1 + requestIDSuffix(e.requestID)\n/; s/(func \(e GenericOpenAPIError\) Model\(\) interface\{\} \{\n\treturn e\.model\n\}\n)/$1\n\/\/ RequestID returns the ID of the request that failed\nfunc (e GenericOpenAPIError) RequestID() string {\n\treturn e.requestID\n}\n/' client.go
+    perl -0pi -e 's/(\t\tnewErr := &GenericOpenAPIError\{\n(\t+)body:  localVarBody,\n)/$1$2requestID: ResponseRequestID(localVarHTTPResponse),\n/g' api_*.go
+
+    # Opt-in client-side validation of request bodies
+    # (see the maintained validate.go)
+    echo "Patching request validation into prepareRequest..."
+    perl -0pi -e 's/(\tDebug            bool              `json:"debug,omitempty"`\n)/$1\t\/\/ ValidateRequests validates request bodies before they are sent; see RecallRequest.Validate.\n\tValidateRequests bool              `json:"validateRequests,omitempty"`\n/' configuration.go
+    perl -0pi -e 's/(\tformFiles \[\]formFile\) \(localVarRequest \*http\.Request, err error\) \{\n)/$1\tif c.cfg.ValidateRequests {\n\t\tif err = validateBody(postBody); err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t}\n/' client.go
+
+    # Type the closed string sets the spec leaves as plain strings
+    # (see the maintained enums.go). retype_field FILE FIELD OLD NEW changes
+    # a model field, its accessors and its constructor argument.
+    echo "Patching typed enums into models and builders..."
+    retype_field() {
+        FIELD="$2" OLD="$3" NEW="$4" perl -0pi -e '
+            my ($f, $old, $new) = @ENV{qw(FIELD OLD NEW)};
+            my $arg = lcfirst $f;
+            s/^(\t\Q$f\E )\Q$old\E( )/$1$new$2/m;
+            s{((?://[^\n]*\n)*func \(o \*\w+\) (?:Get|Set)\Q$f\E(?:Ok)?\(.*?\n\}\n)}{ my $b = $1; $b =~ s/(?<![\w\]])\Q$old\E\b/$new/g; $b }gse;
+            s/(\tvar \Q$arg\E )\Q$old\E( = )/$1$new$2/g;
+            s/(func New\w+\([^)]*\b\Q$arg\E )\Q$old\E\b/$1$new/g;
+        ' "$1"
+    }
+    retype_field model_recall_request.go Types '[]string' '[]FactType'
+    retype_field model_recall_request.go TagsMatch '*string' '*TagsMatch'
+    retype_field model_recall_request.go TagsMatch string TagsMatch
+    retype_field model_reflect_request.go TagsMatch '*string' '*TagsMatch'
+    retype_field model_reflect_request.go TagsMatch string TagsMatch
+    retype_field model_recall_result.go Type NullableString NullableFactType
+    retype_field model_recall_result.go Type string FactType
+    retype_field model_create_bank_request.go RetainExtractionMode NullableString NullableRetainExtractionMode
+    retype_field model_create_bank_request.go RetainExtractionMode string RetainExtractionMode
+    retype_field model_operation_status_response.go Status string OperationStatus
+    retype_field model_operation_response.go Status string OperationStatus
+    retype_field model_child_operation_status.go Status string OperationStatus
+    perl -0pi -e 's/(type Api(?:ClearBankMemories|GetGraph|ListMemories)Request struct \{[^}]*?\ttype_ \*)string/$1FactType/gs; s/(\) Type_\(type_ )string/$1FactType/g' api_memory.go
+    perl -0pi -e 's/(type ApiListOperationsRequest struct \{.*?\tstatus \*)string/$1OperationStatus/s; s/(func \(r ApiListOperationsRequest\) Status\(status )string/$1OperationStatus/' api_operations.go
+    perl -0pi -e 's/(type Api(?:GetGraph|ListDirectives|ListMentalModels)Request struct \{[^}]*?\ttagsMatch \*)string/$1TagsMatch/s; s/(\) TagsMatch\(tagsMatch )string/$1TagsMatch/; s/(\t\tvar defaultValue )string( = "\w+"\n\t\tr\.tagsMatch = )/$1TagsMatch$2/' api_memory.go api_directives.go api_mental_models.go
+
+    # Keep configurations from sharing mutable state (see the maintained
+    # derive.go): AddDefaultHeader replaces the header map instead of writing
+    # to it, and GetConfig points to the derivation methods.
+    echo "Patching configuration copy-on-write..."
+    perl -0pi -e 's/\/\/ AddDefaultHeader adds a new HTTP header to the default header in the request\nfunc \(c \*Configuration\) AddDefaultHeader\(key string, value string\) \{\n\tc\.DefaultHeader\[key\] = value\n\}\n/\/\/ AddDefaultHeader adds a new HTTP header to the default header in the request.\n\/\/ It replaces DefaultHeader with an updated copy instead of writing to it, so\n\/\/ configurations sharing the map, such as struct copies, are not affected.\nfunc (c *Configuration) AddDefaultHeader(key string, value string) {\n\theader := make(map[string]string, len(c.DefaultHeader)+1)\n\tfor k, v := range c.DefaultHeader {\n\t\theader[k] = v\n\t}\n\theader[key] = value\n\tc.DefaultHeader = header\n}\n/' configuration.go
+    perl -0pi -e 's/\/\/ Caution: modifying the configuration while live can cause data races and potentially unwanted behavior\n/\/\/ Caution: the configuration is frozen once the client is in use; modifying it races with\n\/\/ requests in flight. Derive a changed client with Clone or the With methods instead.\n/' client.go
+
+    # Explain unix:// server URLs used without UnixSocketMiddleware (see the
+    # maintained unix_socket.go) instead of reporting an unsupported scheme.
+    perl -0pi -e 's/return resp, withRequestID\(request, err\)\n/return resp, withRequestID(request, unixSocketError(request, err))\n/' client.go
+
     # Initialize module and build
     echo "Building Go client..."
     go mod tidy